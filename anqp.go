@@ -0,0 +1,465 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ANQPInfoID identifies an ANQP element, as used in ANQP_GET commands.
+type ANQPInfoID int
+
+const (
+	ANQPCapabilityList      ANQPInfoID = 257
+	ANQPVenueName           ANQPInfoID = 258
+	ANQPNetworkAuthType     ANQPInfoID = 260
+	ANQPRoamingConsortium   ANQPInfoID = 261
+	ANQPIPAddrAvailability  ANQPInfoID = 262
+	ANQPNAIRealm            ANQPInfoID = 263
+	ANQP3GPPCellularNetwork ANQPInfoID = 264
+	ANQPDomainName          ANQPInfoID = 268
+)
+
+// HS20Subtype identifies a Hotspot 2.0 ANQP element, as used in
+// HS20_ANQP_GET commands.
+type HS20Subtype int
+
+const (
+	HS20CapabilityList       HS20Subtype = 2
+	HS20OperatorFriendlyName HS20Subtype = 3
+	HS20WANMetrics           HS20Subtype = 4
+	HS20ConnectionCapability HS20Subtype = 5
+)
+
+// ErrANQPFailed is returned by QueryANQP() when the access point doesn't
+// answer the query.
+var ErrANQPFailed = errors.New("ANQP query failed")
+
+// ANQPGet queries the access point identified by bssid for the specified
+// ANQP elements, sending ANQP-QUERY-DONE when the response is received.
+// Hotspot 2.0 elements may be requested at the same time.
+func (p *Passpoint) ANQPGet(bssid net.HardwareAddr, ids []ANQPInfoID, subtypes []HS20Subtype) error {
+	var list []string
+	for _, id := range ids {
+		list = append(list, strconv.Itoa(int(id)))
+	}
+	for _, subtype := range subtypes {
+		list = append(list, fmt.Sprintf("hs20:%d", subtype))
+	}
+	return runOK(p.c, fmt.Sprintf("ANQP_GET %s %s", bssid, strings.Join(list, ",")))
+}
+
+// HS20ANQPGet queries the access point identified by bssid for the
+// specified Hotspot 2.0 ANQP elements.
+func (p *Passpoint) HS20ANQPGet(bssid net.HardwareAddr, subtypes ...HS20Subtype) error {
+	list := make([]string, len(subtypes))
+	for i, subtype := range subtypes {
+		list[i] = strconv.Itoa(int(subtype))
+	}
+	return runOK(p.c, fmt.Sprintf("HS20_ANQP_GET %s %s", bssid, strings.Join(list, ",")))
+}
+
+// FetchANQP fetches ANQP information from all interworking-capable access
+// points found by the last scan, sending ANQP-QUERY-DONE for each one.
+func (p *Passpoint) FetchANQP() error {
+	return runOK(p.c, "FETCH_ANQP")
+}
+
+// StopFetchANQP stops an ongoing FetchANQP().
+func (p *Passpoint) StopFetchANQP() error {
+	return runOK(p.c, "STOP_FETCH_ANQP")
+}
+
+// ANQP returns the ANQP information received so far from the access point
+// identified by bssid.
+func (p *Passpoint) ANQP(bssid net.HardwareAddr) (*ANQPInfo, error) {
+	resp, err := p.c.Command("BSS " + bssid.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return parseANQPInfo(resp)
+}
+
+// QueryANQP queries the access point identified by bssid for the specified
+// ANQP elements, waits for the response, and returns the access point's
+// ANQP information.  Use the context to limit how long to wait.
+//
+// QueryANQP consumes the Conn's EventQueue while it runs, so callers should
+// not be reading events from it at the same time.
+func QueryANQP(ctx context.Context, c Conn, bssid net.HardwareAddr, ids []ANQPInfoID, subtypes []HS20Subtype) (*ANQPInfo, error) {
+	p := NewPasspoint(c)
+
+	events := newEventPump(c)
+	defer events.stop()
+
+	if err := p.ANQPGet(bssid, ids, subtypes); err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case ev := <-events.C:
+			done, ok := ParseANQPQueryDone(ev)
+			if !ok || done.Address.String() != bssid.String() {
+				continue
+			}
+			if !done.Success {
+				return nil, ErrANQPFailed
+			}
+			return p.ANQP(bssid)
+		}
+	}
+}
+
+// ANQPQueryDone is an ANQP-QUERY-DONE event, sent when an ANQP query
+// completes.  It looks like:
+//
+//	ANQP-QUERY-DONE addr=02:00:00:00:01:00 result=SUCCESS
+type ANQPQueryDone struct {
+	// Address is the BSSID of the access point which was queried.
+	Address net.HardwareAddr
+
+	// Success is false if the access point didn't answer.
+	Success bool
+}
+
+// ParseANQPQueryDone returns the ANQP-QUERY-DONE event described by ev.
+// The second return value is false if ev isn't an ANQP-QUERY-DONE event.
+func ParseANQPQueryDone(ev WPAEvent) (*ANQPQueryDone, bool) {
+	if ev.Event != "ANQP-QUERY-DONE" {
+		return nil, false
+	}
+
+	args, _ := eventArgs(ev.Line)
+	addr, err := net.ParseMAC(args["addr"])
+	if err != nil {
+		return nil, false
+	}
+
+	return &ANQPQueryDone{
+		Address: addr,
+		Success: args["result"] == "SUCCESS",
+	}, true
+}
+
+// LocalizedName is a name in a particular language, such as a venue or
+// operator name.
+type LocalizedName struct {
+	// Language is the ISO 639 language code, e.g. "eng".
+	Language string
+
+	Name string
+}
+
+// NAIRealm is an entry in the NAI Realm ANQP element, describing realms
+// the access point can authenticate.
+type NAIRealm struct {
+	// Realms are the realm names, e.g. "example.com".
+	Realms []string
+
+	// UTF8 is true if the realms are UTF-8 rather than RFC 4282 names.
+	UTF8 bool
+
+	// EAPMethods are the EAP methods supported for the realms.
+	EAPMethods []NAIRealmEAPMethod
+}
+
+// NAIRealmEAPMethod is an EAP method supported for an NAIRealm.
+type NAIRealmEAPMethod struct {
+	// Method is the EAP method type, e.g. 21 for EAP-TTLS.
+	Method int
+
+	// AuthParams are the method's authentication parameters.
+	AuthParams []NAIRealmAuthParam
+}
+
+// NAIRealmAuthParam is an authentication parameter of an EAP method, such
+// as the non-EAP inner authentication (ID 2) of EAP-TTLS.
+type NAIRealmAuthParam struct {
+	ID    int
+	Value []byte
+}
+
+// PLMN is a cellular network from the 3GPP Cellular Network ANQP element.
+type PLMN struct {
+	// MCC and MNC are the mobile country and network codes, e.g. "310"
+	// and "026".
+	MCC, MNC string
+}
+
+// ANQPInfo is the ANQP information received from an access point.  Elements
+// which weren't received are left empty.
+type ANQPInfo struct {
+	// VenueGroup and VenueType describe the kind of venue, as defined
+	// in IEEE 802.11u.
+	VenueGroup, VenueType int
+
+	// VenueNames are the names of the venue.
+	VenueNames []LocalizedName
+
+	// NAIRealms are the realms the access point can authenticate.
+	NAIRealms []NAIRealm
+
+	// PLMNs are the cellular networks whose subscribers the access point
+	// can authenticate.
+	PLMNs []PLMN
+
+	// DomainNames are the domain names of the operator.
+	DomainNames []string
+
+	// OperatorFriendlyNames are the Hotspot 2.0 names of the operator.
+	OperatorFriendlyNames []LocalizedName
+}
+
+// errTruncatedANQP is returned when an ANQP element is too short.
+var errTruncatedANQP = errors.New("truncated ANQP element")
+
+// parseANQPInfo parses the ANQP elements in a BSS response, which are
+// hex-encoded key=value lines.
+func parseANQPInfo(resp string) (*ANQPInfo, error) {
+	if strings.HasPrefix(resp, "FAIL") || resp == "" {
+		return nil, &ParseError{Line: resp}
+	}
+
+	info := &ANQPInfo{}
+	s := bufio.NewScanner(strings.NewReader(resp))
+	for s.Scan() {
+		kv := strings.SplitN(s.Text(), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		var decode func(*ANQPInfo, []byte) error
+		switch kv[0] {
+		case "anqp_venue_name":
+			decode = decodeVenueName
+		case "anqp_nai_realm":
+			decode = decodeNAIRealms
+		case "anqp_3gpp":
+			decode = decode3GPP
+		case "anqp_domain_name":
+			decode = decodeDomainNames
+		case "hs20_operator_friendly_name":
+			decode = decodeOperatorFriendlyName
+		default:
+			continue
+		}
+
+		b, err := hex.DecodeString(kv[1])
+		if err == nil {
+			err = decode(info, b)
+		}
+		if err != nil {
+			return nil, &ParseError{Line: s.Text(), Err: err}
+		}
+	}
+
+	return info, nil
+}
+
+// decodeLocalizedNames decodes a list of Venue Name or Operator Friendly
+// Name duples, each of which is a length, a three-byte language code, and
+// the name.
+func decodeLocalizedNames(b []byte) ([]LocalizedName, error) {
+	var names []LocalizedName
+	for len(b) > 0 {
+		l := int(b[0])
+		if l < 3 || len(b) < 1+l {
+			return names, errTruncatedANQP
+		}
+
+		names = append(names, LocalizedName{
+			Language: strings.TrimRight(string(b[1:4]), "\x00"),
+			Name:     string(b[4 : 1+l]),
+		})
+		b = b[1+l:]
+	}
+	return names, nil
+}
+
+func decodeVenueName(info *ANQPInfo, b []byte) error {
+	if len(b) < 2 {
+		return errTruncatedANQP
+	}
+	info.VenueGroup, info.VenueType = int(b[0]), int(b[1])
+
+	var err error
+	info.VenueNames, err = decodeLocalizedNames(b[2:])
+	return err
+}
+
+func decodeOperatorFriendlyName(info *ANQPInfo, b []byte) error {
+	var err error
+	info.OperatorFriendlyNames, err = decodeLocalizedNames(b)
+	return err
+}
+
+func decodeDomainNames(info *ANQPInfo, b []byte) error {
+	for len(b) > 0 {
+		l := int(b[0])
+		if len(b) < 1+l {
+			return errTruncatedANQP
+		}
+		info.DomainNames = append(info.DomainNames, string(b[1:1+l]))
+		b = b[1+l:]
+	}
+	return nil
+}
+
+// decodeNAIRealms decodes the NAI Realm element, which is a count followed
+// by that many NAI Realm Data fields.
+func decodeNAIRealms(info *ANQPInfo, b []byte) error {
+	if len(b) < 2 {
+		return errTruncatedANQP
+	}
+	count := int(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+
+	for i := 0; i < count; i++ {
+		if len(b) < 2 {
+			return errTruncatedANQP
+		}
+		l := int(binary.LittleEndian.Uint16(b))
+		if len(b) < 2+l {
+			return errTruncatedANQP
+		}
+
+		realm, err := decodeNAIRealm(b[2 : 2+l])
+		if err != nil {
+			return err
+		}
+		info.NAIRealms = append(info.NAIRealms, realm)
+		b = b[2+l:]
+	}
+	return nil
+}
+
+// decodeNAIRealm decodes an NAI Realm Data field: the encoding, the
+// semicolon-separated realms, and the EAP methods with their
+// authentication parameters.
+func decodeNAIRealm(b []byte) (NAIRealm, error) {
+	var realm NAIRealm
+	if len(b) < 2 || len(b) < 2+int(b[1]) {
+		return realm, errTruncatedANQP
+	}
+	realm.UTF8 = b[0]&0x01 != 0
+	realm.Realms = strings.Split(string(b[2:2+int(b[1])]), ";")
+	b = b[2+int(b[1]):]
+
+	if len(b) < 1 {
+		return realm, errTruncatedANQP
+	}
+	count := int(b[0])
+	b = b[1:]
+
+	for i := 0; i < count; i++ {
+		if len(b) < 1 || len(b) < 1+int(b[0]) {
+			return realm, errTruncatedANQP
+		}
+		m := b[1 : 1+int(b[0])]
+		b = b[1+int(b[0]):]
+
+		if len(m) < 2 {
+			return realm, errTruncatedANQP
+		}
+		method := NAIRealmEAPMethod{Method: int(m[0])}
+		params := int(m[1])
+		m = m[2:]
+
+		for j := 0; j < params; j++ {
+			if len(m) < 2 || len(m) < 2+int(m[1]) {
+				return realm, errTruncatedANQP
+			}
+			method.AuthParams = append(method.AuthParams, NAIRealmAuthParam{
+				ID:    int(m[0]),
+				Value: m[2 : 2+int(m[1])],
+			})
+			m = m[2+int(m[1]):]
+		}
+		realm.EAPMethods = append(realm.EAPMethods, method)
+	}
+	return realm, nil
+}
+
+// decode3GPP decodes the 3GPP Cellular Network element, as defined in 3GPP
+// TS 24.302 Annex H: a version, a header length, and information elements,
+// of which only the PLMN List is used.
+func decode3GPP(info *ANQPInfo, b []byte) error {
+	if len(b) < 2 || len(b) < 2+int(b[1]) {
+		return errTruncatedANQP
+	}
+	b = b[2 : 2+int(b[1])]
+
+	for len(b) > 0 {
+		if len(b) < 2 || len(b) < 2+int(b[1]) {
+			return errTruncatedANQP
+		}
+		iei, ie := b[0], b[2:2+int(b[1])]
+		b = b[2+int(b[1]):]
+		if iei != 0 {
+			continue
+		}
+
+		if len(ie) < 1 || len(ie) < 1+3*int(ie[0]) {
+			return errTruncatedANQP
+		}
+		for i := 0; i < int(ie[0]); i++ {
+			info.PLMNs = append(info.PLMNs, decodePLMN(ie[1+3*i:4+3*i]))
+		}
+	}
+	return nil
+}
+
+// decodePLMN decodes a BCD-encoded PLMN identity.  The third MNC digit is
+// 0xf for two-digit MNCs.
+func decodePLMN(b []byte) PLMN {
+	digit := func(d byte) string {
+		if d > 9 {
+			return ""
+		}
+		return string('0' + d)
+	}
+
+	return PLMN{
+		MCC: digit(b[0]&0x0f) + digit(b[0]>>4) + digit(b[1]&0x0f),
+		MNC: digit(b[2]&0x0f) + digit(b[2]>>4) + digit(b[1]>>4),
+	}
+}
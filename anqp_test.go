@@ -0,0 +1,157 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// anqpBSS is a BSS response with ANQP elements from a Hotspot 2.0 access
+// point.
+const anqpBSS = "id=1\n" +
+	"bssid=02:00:00:00:01:00\n" +
+	"anqp_venue_name=020807656e6743616665\n" +
+	"anqp_nai_realm=01001400000b6578616d706c652e636f6d01051501020104\n" +
+	"anqp_3gpp=0006000401136020\n" +
+	"anqp_domain_name=0b6578616d706c652e636f6d\n" +
+	"hs20_operator_friendly_name=0a656e674578616d706c65\n"
+
+func TestParseANQPInfo(t *testing.T) {
+	info, err := parseANQPInfo(anqpBSS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := &ANQPInfo{
+		VenueGroup: 2,
+		VenueType:  8,
+		VenueNames: []LocalizedName{{"eng", "Cafe"}},
+		NAIRealms: []NAIRealm{{
+			Realms: []string{"example.com"},
+			EAPMethods: []NAIRealmEAPMethod{{
+				Method:     21,
+				AuthParams: []NAIRealmAuthParam{{ID: 2, Value: []byte{4}}},
+			}},
+		}},
+		PLMNs:                 []PLMN{{MCC: "310", MNC: "026"}},
+		DomainNames:           []string{"example.com"},
+		OperatorFriendlyNames: []LocalizedName{{"eng", "Example"}},
+	}
+	if !reflect.DeepEqual(info, expect) {
+		t.Errorf("got %+v", info)
+	}
+
+	for _, resp := range []string{
+		"FAIL\n",
+		"anqp_venue_name=02\n",
+		"anqp_nai_realm=01001400\n",
+		"anqp_3gpp=00060004\n",
+		"anqp_domain_name=0b6578\n",
+		"hs20_operator_friendly_name=zz\n",
+	} {
+		if _, err = parseANQPInfo(resp); err == nil {
+			t.Errorf("expected error parsing %q", resp)
+		}
+	}
+}
+
+func TestDecodePLMN(t *testing.T) {
+	if plmn := decodePLMN([]byte{0x62, 0xf2, 0x10}); plmn.MCC != "262" || plmn.MNC != "01" {
+		t.Errorf("got %+v", plmn)
+	}
+}
+
+func TestQueryANQP(t *testing.T) {
+	var fs *fakeServer
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch cmd {
+		case "ANQP_GET 02:00:00:00:01:00 258,263,hs20:3":
+			go func() {
+				fs.event("ANQP-QUERY-DONE addr=02:00:00:00:02:00 result=FAILURE")
+				fs.event("ANQP-QUERY-DONE addr=02:00:00:00:01:00 result=SUCCESS")
+			}()
+		case "BSS 02:00:00:00:01:00":
+			return anqpBSS
+		}
+		return ""
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	bssid := net.HardwareAddr{2, 0, 0, 0, 1, 0}
+	info, err := QueryANQP(ctx, conn, bssid, []ANQPInfoID{ANQPVenueName, ANQPNAIRealm}, []HS20Subtype{HS20OperatorFriendlyName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.VenueNames) != 1 || len(info.OperatorFriendlyNames) != 1 {
+		t.Errorf("got %+v", info)
+	}
+
+	p := NewPasspoint(conn)
+	if err = p.HS20ANQPGet(bssid, HS20WANMetrics, HS20ConnectionCapability); err != nil {
+		t.Error(err)
+	}
+	if err = p.FetchANQP(); err != nil {
+		t.Error(err)
+	}
+	if err = p.StopFetchANQP(); err != nil {
+		t.Error(err)
+	}
+
+	fs.expectCommands(
+		"ANQP_GET 02:00:00:00:01:00 258,263,hs20:3",
+		"BSS 02:00:00:00:01:00",
+		"HS20_ANQP_GET 02:00:00:00:01:00 4,5",
+		"FETCH_ANQP",
+		"STOP_FETCH_ANQP",
+	)
+}
+
+func TestQueryANQPFailure(t *testing.T) {
+	var fs *fakeServer
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		if cmd == "ANQP_GET 02:00:00:00:01:00 268" {
+			go fs.event("ANQP-QUERY-DONE addr=02:00:00:00:01:00 result=FAILURE")
+		}
+		return ""
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := QueryANQP(ctx, conn, net.HardwareAddr{2, 0, 0, 0, 1, 0}, []ANQPInfoID{ANQPDomainName}, nil)
+	if err != ErrANQPFailed {
+		t.Errorf("expected ErrANQPFailed, got %v", err)
+	}
+}
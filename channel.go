@@ -0,0 +1,188 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"fmt"
+)
+
+// Band is a frequency band.
+type Band int
+
+const (
+	BandUnknown Band = iota
+	Band2GHz
+	Band5GHz
+	Band6GHz
+	Band60GHz
+)
+
+func (b Band) String() string {
+	switch b {
+	case Band2GHz:
+		return "2.4 GHz"
+	case Band5GHz:
+		return "5 GHz"
+	case Band6GHz:
+		return "6 GHz"
+	case Band60GHz:
+		return "60 GHz"
+	}
+	return "unknown"
+}
+
+// Channel is a 20 MHz channel (or 2.16 GHz channel, in the 60 GHz band).
+type Channel struct {
+	// Number is the channel number within its band.
+	Number int
+
+	// Band is the frequency band.
+	Band Band
+
+	// Frequency is the center frequency, in Mhz.
+	Frequency int
+}
+
+// FrequencyToChannel returns the channel with the specified center
+// frequency, in Mhz.  The second return value is false if the frequency is
+// not a known channel.  As in wpa_supplicant, the Japanese 4.9 GHz channels
+// are numbered 182 to 196 in Band5GHz.
+func FrequencyToChannel(freq int) (Channel, bool) {
+	c := Channel{Frequency: freq}
+
+	switch {
+	case freq == 2484:
+		c.Number, c.Band = 14, Band2GHz
+	case freq >= 2412 && freq <= 2472 && (freq-2407)%5 == 0:
+		c.Number, c.Band = (freq-2407)/5, Band2GHz
+	case freq == 5935:
+		// 6 GHz channel 2 is the odd one out.
+		c.Number, c.Band = 2, Band6GHz
+	case freq >= 5955 && freq <= 7115 && (freq-5955)%20 == 0:
+		// Other 6 GHz channels are every 20 MHz, numbered 1, 5,
+		// 9, ...
+		c.Number, c.Band = (freq-5950)/5, Band6GHz
+	case freq >= 4910 && freq <= 4980 && freq%5 == 0:
+		// Japanese 4.9 GHz channels are numbered as part of the 5
+		// GHz band, after channel 177 so that the numbers don't
+		// overlap.
+		c.Number, c.Band = (freq-4000)/5, Band5GHz
+	case freq >= 5005 && freq <= 5885 && freq%5 == 0:
+		c.Number, c.Band = (freq-5000)/5, Band5GHz
+	case freq >= 58320 && freq <= 70200 && (freq-56160)%2160 == 0:
+		c.Number, c.Band = (freq-56160)/2160, Band60GHz
+	default:
+		return Channel{}, false
+	}
+
+	return c, true
+}
+
+// ChannelToFrequency returns the center frequency, in Mhz, of a channel.
+// The second return value is false if there is no such channel.
+func ChannelToFrequency(number int, band Band) (int, bool) {
+	var freq int
+
+	switch band {
+	case Band2GHz:
+		switch {
+		case number == 14:
+			freq = 2484
+		case number >= 1 && number <= 13:
+			freq = 2407 + number*5
+		}
+	case Band5GHz:
+		switch {
+		case number >= 182 && number <= 196:
+			freq = 4000 + number*5
+		case number >= 1 && number <= 177:
+			freq = 5000 + number*5
+		}
+	case Band6GHz:
+		switch {
+		case number == 2:
+			freq = 5935
+		case number >= 1 && number <= 233 && number%4 == 1:
+			freq = 5950 + number*5
+		}
+	case Band60GHz:
+		if number >= 1 && number <= 6 {
+			freq = 56160 + number*2160
+		}
+	}
+
+	if c, ok := FrequencyToChannel(freq); !ok || c.Number != number || c.Band != band {
+		return 0, false
+	}
+	return freq, true
+}
+
+// DFS returns true if the channel requires radar detection (dynamic
+// frequency selection), i.e. it is a 5 GHz channel between 52 and 144.
+func (c Channel) DFS() bool {
+	return c.Band == Band5GHz && c.Number >= 52 && c.Number <= 144
+}
+
+// OperatingClass returns the global operating class (IEEE 802.11 Annex E,
+// Table E-4) of the channel when used as a 20 MHz channel, or 0 if it has
+// none.
+func (c Channel) OperatingClass() int {
+	switch c.Band {
+	case Band2GHz:
+		if c.Number == 14 {
+			return 82
+		}
+		return 81
+	case Band5GHz:
+		switch {
+		case c.Number >= 36 && c.Number <= 48:
+			return 115
+		case c.Number >= 52 && c.Number <= 64:
+			return 118
+		case c.Number >= 100 && c.Number <= 144:
+			return 121
+		case c.Number >= 149 && c.Number <= 161:
+			return 124
+		case c.Number >= 165 && c.Number <= 177:
+			return 125
+		}
+	case Band6GHz:
+		if c.Number == 2 {
+			return 136
+		}
+		return 131
+	case Band60GHz:
+		return 180
+	}
+	return 0
+}
+
+func (c Channel) String() string {
+	return fmt.Sprintf("%d (%s)", c.Number, c.Band)
+}
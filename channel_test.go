@@ -0,0 +1,126 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bytes"
+	"testing"
+)
+
+var channelTests = []struct {
+	freq           int
+	number         int
+	band           Band
+	dfs            bool
+	operatingClass int
+}{
+	{2412, 1, Band2GHz, false, 81},
+	{2437, 6, Band2GHz, false, 81},
+	{2472, 13, Band2GHz, false, 81},
+	{2484, 14, Band2GHz, false, 82},
+	{5180, 36, Band5GHz, false, 115},
+	{5260, 52, Band5GHz, true, 118},
+	{5500, 100, Band5GHz, true, 121},
+	{5720, 144, Band5GHz, true, 121},
+	{5745, 149, Band5GHz, false, 124},
+	{5825, 165, Band5GHz, false, 125},
+	{5005, 1, Band5GHz, false, 0},
+	{5885, 177, Band5GHz, false, 125},
+	{4910, 182, Band5GHz, false, 0},
+	{4920, 184, Band5GHz, false, 0},
+	{4980, 196, Band5GHz, false, 0},
+	{5935, 2, Band6GHz, false, 136},
+	{5955, 1, Band6GHz, false, 131},
+	{6115, 33, Band6GHz, false, 131},
+	{7115, 233, Band6GHz, false, 131},
+	{58320, 1, Band60GHz, false, 180},
+	{69120, 6, Band60GHz, false, 180},
+}
+
+func TestFrequencyToChannel(t *testing.T) {
+	for _, test := range channelTests {
+		c, ok := FrequencyToChannel(test.freq)
+		if !ok {
+			t.Errorf("%d Mhz not recognized", test.freq)
+			continue
+		}
+		if c.Number != test.number || c.Band != test.band {
+			t.Errorf("wrong channel for %d Mhz (got %s, expect %d (%s))", test.freq, c, test.number, test.band)
+		}
+		if c.DFS() != test.dfs {
+			t.Errorf("wrong DFS for %d Mhz (got %t)", test.freq, c.DFS())
+		}
+		if c.OperatingClass() != test.operatingClass {
+			t.Errorf("wrong operating class for %d Mhz (got %d, expect %d)", test.freq, c.OperatingClass(), test.operatingClass)
+		}
+
+		if freq, ok := ChannelToFrequency(test.number, test.band); !ok || freq != test.freq {
+			t.Errorf("wrong frequency for channel %d (%s) (got %d, expect %d)", test.number, test.band, freq, test.freq)
+		}
+	}
+
+	for _, freq := range []int{0, 2400, 2413, 3000, 4905, 4985, 5000, 5001, 5890, 5920, 5960, 5965, 5970, 7120, 8000} {
+		if c, ok := FrequencyToChannel(freq); ok {
+			t.Errorf("unexpected channel %s for %d Mhz", c, freq)
+		}
+	}
+
+	for _, number := range []int{0, 178, 181, 197} {
+		if freq, ok := ChannelToFrequency(number, Band5GHz); ok {
+			t.Errorf("unexpected frequency %d for 5 GHz channel %d", freq, number)
+		}
+	}
+
+	for _, number := range []int{0, 3, 4, 6, 232, 237} {
+		if freq, ok := ChannelToFrequency(number, Band6GHz); ok {
+			t.Errorf("unexpected frequency %d for 6 GHz channel %d", freq, number)
+		}
+	}
+}
+
+func TestScanResultChannel(t *testing.T) {
+	input := "bssid / frequency / signal level / flags / ssid\n" +
+		"8a:15:14:8a:46:51\t5560\t-58\t[ESS]\tfoo\n"
+
+	res, errs := parseScanResults(bytes.NewBufferString(input))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if c, ok := FrequencyToChannel(res[0].Frequency()); !ok || c.Number != 112 || c.Band != Band5GHz {
+		t.Errorf("wrong channel (got %d, %s)", c.Number, c.Band)
+	}
+
+	status, err := parseStatusResults(bytes.NewBufferString("freq=2437\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := FrequencyToChannel(status.Frequency()); !ok || c.Number != 6 || c.Band != Band2GHz {
+		t.Errorf("wrong channel (got %d, %s)", c.Number, c.Band)
+	}
+}
@@ -0,0 +1,488 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Command wpactl is a scriptable alternative to wpa_cli.
+//
+// Usage:
+//
+//	wpactl [--iface wlan0] [--ctrl-dir /run/wpa_supplicant] [--json] <command> [args]
+//
+// Commands:
+//
+//	status                          show the interface status
+//	scan [--wait] [--timeout 10s]   trigger a scan, optionally printing the results
+//	networks                        list configured networks
+//	add --ssid S [--psk P] [...]    add a network, printing its ID
+//	connect <id>                    select a configured network
+//	connect --ssid S [--psk P]      add a network and wait for it to connect
+//	remove <id>|all                 remove networks
+//	events [--follow]               print the next event, or all events
+//	signal                          show link quality
+//
+// With --json, each command prints a single JSON document (or, for events
+// --follow, one per line).
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"pifke.org/wpasupplicant"
+	"pifke.org/wpasupplicant/wpajson"
+)
+
+// options are the global command-line options.
+type options struct {
+	iface   string
+	ctrlDir string
+	json    bool
+	out     io.Writer
+
+	// stopDiscarding stops discarding events, for commands which wait
+	// for them.
+	stopDiscarding func()
+}
+
+// command is a wpactl subcommand.
+type command struct {
+	usage string
+	run   func(opts *options, c wpasupplicant.Conn, args []string) error
+}
+
+var commands = map[string]command{
+	"status":   {"status", runStatus},
+	"scan":     {"scan [--wait] [--timeout duration]", runScan},
+	"networks": {"networks", runNetworks},
+	"add":      {"add --ssid ssid [--psk psk] [--key-mgmt list] [--priority n] [--hidden] [--enable] [--var name=value ...]", runAdd},
+	"connect":  {"connect id | connect --ssid ssid [--psk psk] [--key-mgmt list] [--timeout duration]", runConnect},
+	"remove":   {"remove id|all", runRemove},
+	"events":   {"events [--follow]", runEvents},
+	"signal":   {"signal", runSignal},
+}
+
+func main() {
+	opts := &options{out: os.Stdout}
+	flag.StringVar(&opts.iface, "iface", "wlan0", "network interface")
+	flag.StringVar(&opts.ctrlDir, "ctrl-dir", "/run/wpa_supplicant", "wpa_supplicant control interface directory")
+	flag.BoolVar(&opts.json, "json", false, "print output as JSON")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "wpactl: unknown command %q\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	c, err := wpasupplicant.UnixgramDir(opts.ctrlDir, opts.iface)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wpactl: %s\n", err)
+		os.Exit(1)
+	}
+	defer c.Close()
+	opts.stopDiscarding = discardEvents(c)
+
+	if err = cmd.run(opts, c, flag.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "wpactl: %s: %s\n", flag.Arg(0), err)
+		c.Close()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: wpactl [flags] command [args]\n\nFlags:\n")
+	flag.PrintDefaults()
+
+	fmt.Fprintf(os.Stderr, "\nCommands:\n")
+	for _, name := range []string{"status", "scan", "networks", "add", "connect", "remove", "events", "signal"} {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+}
+
+// discardEvents reads and discards events from the Conn's EventQueue until
+// the returned function is called.  The connection is always attached to
+// receive events, and an unread event blocks the responses to commands.
+func discardEvents(c wpasupplicant.Conn) (stop func()) {
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-c.EventQueue():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+// print writes v as JSON if --json was specified, and calls text otherwise.
+func (opts *options) print(v interface{}, text func(w io.Writer)) error {
+	if opts.json {
+		enc := json.NewEncoder(opts.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(opts.out, 0, 8, 2, ' ', 0)
+	text(tw)
+	return tw.Flush()
+}
+
+func runStatus(opts *options, c wpasupplicant.Conn, args []string) error {
+	s, err := c.Status()
+	if err != nil {
+		return err
+	}
+
+	status := wpajson.NewStatus(s)
+	return opts.print(status, func(w io.Writer) {
+		fmt.Fprintf(w, "wpa_state:\t%s\n", status.WPAState)
+		if status.SSID != "" {
+			fmt.Fprintf(w, "ssid:\t%s\n", status.SSID)
+			fmt.Fprintf(w, "bssid:\t%s\n", status.BSSID)
+			fmt.Fprintf(w, "frequency:\t%d (channel %d)\n", status.Frequency, status.Channel)
+			fmt.Fprintf(w, "key_mgmt:\t%s\n", status.KeyMgmt)
+		}
+		if status.IPAddr != "" {
+			fmt.Fprintf(w, "ip_address:\t%s\n", status.IPAddr)
+		}
+		if status.Address != "" {
+			fmt.Fprintf(w, "address:\t%s\n", status.Address)
+		}
+	})
+}
+
+func runScan(opts *options, c wpasupplicant.Conn, args []string) error {
+	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	wait := fs.Bool("wait", false, "wait for and print the scan results")
+	timeout := fs.Duration("timeout", 10*time.Second, "how long to wait for results")
+	fs.Parse(args)
+
+	if !*wait {
+		return c.Scan()
+	}
+	opts.stopDiscarding()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	results, errs := wpasupplicant.ScanAndWait(ctx, c)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	bss := make([]wpajson.BSS, 0, len(results))
+	for _, r := range results {
+		bss = append(bss, wpajson.NewBSS(r))
+	}
+	return opts.print(bss, func(w io.Writer) {
+		fmt.Fprintf(w, "BSSID\tFREQ\tCH\tRSSI\tFLAGS\tSSID\n")
+		for _, b := range bss {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\n", b.BSSID, b.Frequency, b.Channel, b.RSSI, formatFlags(b.Flags), b.SSID)
+		}
+	})
+}
+
+func runNetworks(opts *options, c wpasupplicant.Conn, args []string) error {
+	networks, err := c.ListNetworks()
+	if err != nil {
+		return err
+	}
+
+	res := make([]wpajson.Network, 0, len(networks))
+	for _, n := range networks {
+		res = append(res, wpajson.NewNetwork(n))
+	}
+	return opts.print(res, func(w io.Writer) {
+		fmt.Fprintf(w, "ID\tBSSID\tFLAGS\tSSID\n")
+		for _, n := range res {
+			bssid := n.BSSID
+			if bssid == "" {
+				bssid = "any"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", n.ID, bssid, formatFlags(n.Flags), n.SSID)
+		}
+	})
+}
+
+// networkFlags defines the flags used to describe a network.
+func networkFlags(fs *flag.FlagSet) func() (wpasupplicant.NetworkConfig, error) {
+	ssid := fs.String("ssid", "", "SSID")
+	psk := fs.String("psk", "", "WPA passphrase, or 64 hex digit PSK")
+	keyMgmt := fs.String("key-mgmt", "", "key management protocols, e.g. WPA-PSK")
+	priority := fs.Int("priority", 0, "priority group")
+	hidden := fs.Bool("hidden", false, "scan for a hidden SSID")
+	var vars varsFlag
+	fs.Var(&vars, "var", "set a network variable (name=value, encoded as in wpa_supplicant.conf); may be repeated")
+
+	return func() (wpasupplicant.NetworkConfig, error) {
+		cfg := wpasupplicant.NetworkConfig{
+			SSID:     *ssid,
+			PSK:      *psk,
+			KeyMgmt:  *keyMgmt,
+			Priority: *priority,
+			ScanSSID: *hidden,
+		}
+		if cfg.SSID == "" {
+			return cfg, errors.New("--ssid is required")
+		}
+
+		for _, v := range vars {
+			kv := strings.SplitN(v, "=", 2)
+			if len(kv) != 2 {
+				return cfg, fmt.Errorf("invalid --var %q", v)
+			}
+			if err := cfg.Set(kv[0], kv[1]); err != nil {
+				return cfg, err
+			}
+		}
+		return cfg, nil
+	}
+}
+
+// varsFlag is a repeatable string flag.
+type varsFlag []string
+
+func (v *varsFlag) String() string     { return strings.Join(*v, ",") }
+func (v *varsFlag) Set(s string) error { *v = append(*v, s); return nil }
+
+func runAdd(opts *options, c wpasupplicant.Conn, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	config := networkFlags(fs)
+	enable := fs.Bool("enable", false, "enable the network")
+	fs.Parse(args)
+
+	cfg, err := config()
+	if err != nil {
+		return err
+	}
+
+	id, err := c.AddNetwork()
+	if err != nil {
+		return err
+	}
+	if err = c.ApplyNetwork(id, cfg); err != nil {
+		c.RemoveNetwork(id)
+		return err
+	}
+	if *enable {
+		if err = c.EnableNetwork(id); err != nil {
+			return err
+		}
+	}
+
+	return opts.print(map[string]int{"id": id}, func(w io.Writer) {
+		fmt.Fprintf(w, "%d\n", id)
+	})
+}
+
+func runConnect(opts *options, c wpasupplicant.Conn, args []string) error {
+	fs := flag.NewFlagSet("connect", flag.ExitOnError)
+	config := networkFlags(fs)
+	timeout := fs.Duration("timeout", 30*time.Second, "how long to wait for the connection")
+	keep := fs.Bool("keep", false, "keep the network if the connection fails")
+	fs.Parse(args)
+
+	if fs.NArg() == 1 {
+		id, err := strconv.Atoi(fs.Arg(0))
+		if err != nil {
+			return fmt.Errorf("invalid network id %q", fs.Arg(0))
+		}
+		return c.SelectNetwork(id)
+	}
+
+	cfg, err := config()
+	if err != nil {
+		return err
+	}
+	opts.stopDiscarding()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	var res wpajson.ConnectResult
+	r, err := wpasupplicant.Connect(ctx, c, cfg, &wpasupplicant.ConnectOptions{RemoveOnFailure: !*keep})
+	if cerr, ok := err.(*wpasupplicant.ConnectError); ok {
+		res = wpajson.ConnectResult{
+			ID:         cerr.NetworkID,
+			Reason:     cerr.Reason.String(),
+			StatusCode: cerr.StatusCode,
+		}
+	} else if err != nil {
+		return err
+	} else {
+		res = wpajson.ConnectResult{
+			Connected: true,
+			ID:        r.NetworkID,
+			BSSID:     r.BSSID.String(),
+			Frequency: r.Frequency,
+		}
+	}
+
+	if perr := opts.print(res, func(w io.Writer) {
+		if res.Connected {
+			fmt.Fprintf(w, "connected to %s (%d Mhz) as network %d\n", res.BSSID, res.Frequency, res.ID)
+		}
+	}); perr != nil {
+		return perr
+	}
+	return err
+}
+
+func runRemove(opts *options, c wpasupplicant.Conn, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: remove id|all")
+	}
+
+	if args[0] == "all" {
+		return c.RemoveAllNetworks()
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid network id %q", args[0])
+	}
+	return c.RemoveNetwork(id)
+}
+
+// Event is the JSON form of wpasupplicant.WPAEvent.
+type Event struct {
+	Time      time.Time         `json:"time"`
+	Event     string            `json:"event"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+	Line      string            `json:"line"`
+}
+
+func runEvents(opts *options, c wpasupplicant.Conn, args []string) error {
+	fs := flag.NewFlagSet("events", flag.ExitOnError)
+	follow := fs.Bool("follow", false, "print events until interrupted")
+	fs.Parse(args)
+	opts.stopDiscarding()
+
+	for ev := range c.EventQueue() {
+		e := Event{
+			Time:      time.Now(),
+			Event:     ev.Event,
+			Arguments: ev.Arguments,
+			Line:      ev.Line,
+		}
+
+		var err error
+		if opts.json {
+			// One event per line, so the output can be streamed.
+			err = json.NewEncoder(opts.out).Encode(e)
+		} else {
+			_, err = fmt.Fprintf(opts.out, "%s %s\n", e.Time.Format(time.RFC3339), e.Line)
+		}
+		if err != nil || !*follow {
+			return err
+		}
+	}
+	return nil
+}
+
+// Signal is the JSON form of wpasupplicant.SignalPollResult and
+// wpasupplicant.PacketCountResult.
+type Signal struct {
+	RSSI        int    `json:"rssi"`
+	AverageRSSI int    `json:"avg_rssi"`
+	LinkSpeed   int    `json:"link_speed"`
+	Noise       *int   `json:"noise,omitempty"`
+	Frequency   int    `json:"frequency"`
+	Width       string `json:"width"`
+	TxPackets   uint64 `json:"tx_packets"`
+	TxFailures  uint64 `json:"tx_failures"`
+	RxPackets   uint64 `json:"rx_packets"`
+}
+
+func runSignal(opts *options, c wpasupplicant.Conn, args []string) error {
+	s, err := c.SignalPoll()
+	if err != nil {
+		return err
+	}
+	p, err := c.PacketCountPoll()
+	if err != nil {
+		return err
+	}
+
+	sig := Signal{
+		RSSI:        s.RSSI(),
+		AverageRSSI: s.AverageRSSI(),
+		LinkSpeed:   s.LinkSpeed(),
+		Frequency:   s.Frequency(),
+		Width:       s.Width(),
+		TxPackets:   p.TxPackets(),
+		TxFailures:  p.TxFailures(),
+		RxPackets:   p.RxPackets(),
+	}
+	if noise := s.Noise(); noise != 9999 {
+		sig.Noise = &noise
+	}
+
+	return opts.print(sig, func(w io.Writer) {
+		fmt.Fprintf(w, "rssi:\t%d dBm (average %d dBm)\n", sig.RSSI, sig.AverageRSSI)
+		if sig.Noise != nil {
+			fmt.Fprintf(w, "noise:\t%d dBm\n", *sig.Noise)
+		}
+		fmt.Fprintf(w, "link_speed:\t%d Mbps\n", sig.LinkSpeed)
+		fmt.Fprintf(w, "frequency:\t%d Mhz (%s)\n", sig.Frequency, sig.Width)
+		fmt.Fprintf(w, "tx_packets:\t%d (%d failed)\n", sig.TxPackets, sig.TxFailures)
+		fmt.Fprintf(w, "rx_packets:\t%d\n", sig.RxPackets)
+	})
+}
+
+func formatFlags(flags []string) string {
+	if len(flags) == 0 {
+		return "-"
+	}
+	return "[" + strings.Join(flags, "][") + "]"
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Command wpad serves the wpa_supplicant control interface over HTTP, for
+// processes which can't access the control socket.  See package
+// pifke.org/wpasupplicant/server for the API.
+//
+// Usage:
+//
+//	wpad [--iface wlan0] [--ctrl-dir /run/wpa_supplicant] [--listen 127.0.0.1:8080] [--token-file file]
+//
+// The API token is read from the file given by --token-file, or from the
+// WPAD_TOKEN environment variable.  Use --no-auth to serve without one.
+package main
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"pifke.org/wpasupplicant"
+	"pifke.org/wpasupplicant/server"
+)
+
+func main() {
+	iface := flag.String("iface", "wlan0", "network interface")
+	ctrlDir := flag.String("ctrl-dir", "/run/wpa_supplicant", "wpa_supplicant control interface directory")
+	listen := flag.String("listen", "127.0.0.1:8080", "address to listen on")
+	tokenFile := flag.String("token-file", "", "file containing the API token")
+	noAuth := flag.Bool("no-auth", false, "don't require an API token")
+	flag.Parse()
+
+	token := os.Getenv("WPAD_TOKEN")
+	if *tokenFile != "" {
+		b, err := ioutil.ReadFile(*tokenFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		token = strings.TrimSpace(string(b))
+	}
+	if token == "" && !*noAuth {
+		log.Fatal("no API token: use --token-file, WPAD_TOKEN or --no-auth")
+	}
+
+	c, err := wpasupplicant.UnixgramDir(*ctrlDir, *iface)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	s := server.New(c, token)
+	go s.Run(ctx)
+
+	hs := &http.Server{
+		Addr:    *listen,
+		Handler: s,
+		// Cancelling requests on shutdown ends the event streams.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		hs.Shutdown(context.Background())
+	}()
+
+	log.Printf("serving %s on %s", *iface, *listen)
+	if err = hs.ListenAndServe(); err != http.ErrServerClosed {
+		log.Print(err)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ConnectFailure is the reason a connection attempt failed.
+type ConnectFailure int
+
+const (
+	// ConnectTimeout means the context expired before the connection
+	// completed.
+	ConnectTimeout ConnectFailure = iota
+
+	// ConnectWrongKey means the network was disabled after a 4-way
+	// handshake failure, which usually means the pre-shared key is
+	// incorrect.
+	ConnectWrongKey
+
+	// ConnectNotFound means a scan completed without finding the network.
+	ConnectNotFound
+
+	// ConnectAssocRejected means the access point rejected our
+	// association.  See ConnectError.StatusCode for the reason.
+	ConnectAssocRejected
+
+	// ConnectEAPFailure means EAP authentication failed.
+	ConnectEAPFailure
+)
+
+func (f ConnectFailure) String() string {
+	switch f {
+	case ConnectTimeout:
+		return "timed out"
+	case ConnectWrongKey:
+		return "wrong key"
+	case ConnectNotFound:
+		return "network not found"
+	case ConnectAssocRejected:
+		return "association rejected"
+	case ConnectEAPFailure:
+		return "EAP authentication failed"
+	}
+	return fmt.Sprintf("ConnectFailure(%d)", int(f))
+}
+
+// ConnectError is returned by Connect() when wpa_supplicant fails to
+// connect to the network.
+type ConnectError struct {
+	// Reason is why the connection failed.
+	Reason ConnectFailure
+
+	// StatusCode is the IEEE 802.11 status code sent by the access point
+	// when rejecting association.  It is only set if Reason is
+	// ConnectAssocRejected.
+	StatusCode int
+
+	// NetworkID is the ID of the network which failed to connect, or -1
+	// if it was removed.
+	NetworkID int
+
+	// Line is the event from wpa_supplicant which reported the failure,
+	// if any.
+	Line string
+
+	// Err is any nested error, such as the context's error on timeout.
+	Err error
+}
+
+func (err *ConnectError) Error() string {
+	msg := "failed to connect: " + err.Reason.String()
+
+	if err.Reason == ConnectAssocRejected {
+		msg += fmt.Sprintf(" (status code %d)", err.StatusCode)
+	}
+
+	if err.Err != nil {
+		msg += ": " + err.Err.Error()
+	}
+
+	return msg
+}
+
+// ConnectResult describes a successful connection.
+type ConnectResult struct {
+	// NetworkID is the ID of the network which was connected.
+	NetworkID int
+
+	// BSSID is the MAC address of the BSS we connected to.
+	BSSID net.HardwareAddr
+
+	// Frequency is the frequency, in Mhz, of the BSS we connected to.
+	Frequency int
+}
+
+// ConnectOptions modifies the behavior of Connect().
+type ConnectOptions struct {
+	// RemoveOnFailure removes the network added by Connect() if the
+	// connection fails.
+	RemoveOnFailure bool
+}
+
+// Connect adds a network using cfg, selects it, and waits until
+// wpa_supplicant either connects to it or reports a failure.  Failures to
+// connect are returned as a *ConnectError.  Use the context to limit how
+// long to wait.
+//
+// If the connection fails, the networks which were enabled beforehand are
+// enabled again.  Connect consumes the Conn's EventQueue while it runs, so
+// callers should not be reading events from it at the same time.
+func Connect(ctx context.Context, c Conn, cfg NetworkConfig, opts *ConnectOptions) (*ConnectResult, error) {
+	if opts == nil {
+		opts = &ConnectOptions{}
+	}
+
+	events := newEventPump(c)
+	defer events.stop()
+
+	networkID, err := c.AddNetwork()
+	if err != nil {
+		return nil, err
+	}
+
+	// SELECT_NETWORK disables every other network, so on failure the
+	// networks which were enabled are enabled again, letting
+	// wpa_supplicant reconnect to one of them.
+	var enabled []int
+	fail := func(err error) (*ConnectResult, error) {
+		if opts.RemoveOnFailure {
+			c.RemoveNetwork(networkID)
+			if cerr, ok := err.(*ConnectError); ok {
+				cerr.NetworkID = -1
+			}
+		}
+		for _, id := range enabled {
+			c.EnableNetwork(id)
+		}
+		return nil, err
+	}
+
+	// wpa_supplicant defaults to "WPA-PSK WPA-EAP", which won't connect
+	// to an open or SAE-only network.
+	if cfg.KeyMgmt == "" && cfg.PSK == "" && cfg.EAP == "" {
+		if cfg.SAEPassword != "" {
+			cfg.KeyMgmt = "SAE"
+		} else {
+			cfg.KeyMgmt = "NONE"
+		}
+	}
+
+	if err = c.ApplyNetwork(networkID, cfg); err != nil {
+		return fail(err)
+	}
+
+	networks, err := c.ListNetworks()
+	if err != nil {
+		return fail(err)
+	}
+	for _, n := range networks {
+		if id := n.NetworkID(); id != networkID && !n.Disabled() && !n.P2PPersistent() {
+			enabled = append(enabled, id)
+		}
+	}
+
+	if err = c.SelectNetwork(networkID); err != nil {
+		return fail(err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fail(&ConnectError{
+				Reason:    ConnectTimeout,
+				NetworkID: networkID,
+				Err:       ctx.Err(),
+			})
+
+		case ev := <-events.C:
+			switch ev.Event {
+			case "CONNECTED":
+				bssid, id, ok := parseConnectedEvent(ev.Line)
+				if !ok || id != networkID {
+					continue
+				}
+
+				res := &ConnectResult{
+					NetworkID: networkID,
+					BSSID:     bssid,
+				}
+				if status, err := c.Status(); err == nil {
+					res.Frequency = status.Frequency()
+					if status.BSSID() != nil {
+						res.BSSID = status.BSSID()
+					}
+				}
+				return res, nil
+
+			case "SSID-TEMP-DISABLED":
+				if ev.Arguments["id"] != strconv.Itoa(networkID) || ev.Arguments["reason"] != "WRONG_KEY" {
+					continue
+				}
+				return fail(&ConnectError{
+					Reason:    ConnectWrongKey,
+					NetworkID: networkID,
+					Line:      ev.Line,
+				})
+
+			case "NETWORK-NOT-FOUND":
+				return fail(&ConnectError{
+					Reason:    ConnectNotFound,
+					NetworkID: networkID,
+					Line:      ev.Line,
+				})
+
+			case "ASSOC-REJECT":
+				statusCode, _ := strconv.Atoi(ev.Arguments["status_code"])
+				return fail(&ConnectError{
+					Reason:     ConnectAssocRejected,
+					StatusCode: statusCode,
+					NetworkID:  networkID,
+					Line:       ev.Line,
+				})
+
+			case "EAP-FAILURE":
+				return fail(&ConnectError{
+					Reason:    ConnectEAPFailure,
+					NetworkID: networkID,
+					Line:      ev.Line,
+				})
+			}
+		}
+	}
+}
+
+// parseConnectedEvent extracts the BSSID and network ID from a
+// CTRL-EVENT-CONNECTED message, which looks like:
+//
+//	CTRL-EVENT-CONNECTED - Connection to 02:00:00:00:01:00 completed [id=0 id_str=]
+func parseConnectedEvent(line string) (bssid net.HardwareAddr, id int, ok bool) {
+	fields := strings.Fields(line)
+	id = -1
+
+	for i, f := range fields {
+		if f == "to" && i+1 < len(fields) {
+			bssid, _ = net.ParseMAC(fields[i+1])
+		}
+
+		if strings.HasPrefix(f, "[id=") {
+			n, err := strconv.Atoi(strings.TrimPrefix(f, "[id="))
+			if err != nil {
+				return nil, -1, false
+			}
+			id, ok = n, true
+		}
+	}
+
+	return
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// connectServer starts a fakeServer which behaves like wpa_supplicant adding
+// network 0, alongside enabled network 1 and disabled network 2, and sends
+// the specified events once the network is selected.
+func connectServer(t *testing.T, events ...string) (*fakeServer, Conn) {
+	var fs *fakeServer
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch cmd {
+		case "ADD_NETWORK":
+			return "0\n"
+		case "LIST_NETWORKS":
+			return "network id / ssid / bssid / flags\n" +
+				"0\ttest\tany\t[DISABLED]\n" +
+				"1\thome\tany\t[CURRENT]\n" +
+				"2\told\tany\t[DISABLED]\n"
+		case "SELECT_NETWORK 0":
+			go func() {
+				for _, ev := range events {
+					fs.event(ev)
+				}
+			}()
+		case "STATUS":
+			return "bssid=02:00:00:00:01:00\nfreq=2412\nssid=test\nid=0\nwpa_state=COMPLETED\n"
+		}
+		return ""
+	})
+	return fs, conn
+}
+
+func TestConnect(t *testing.T) {
+	fs, conn := connectServer(t,
+		"CTRL-EVENT-SCAN-RESULTS ",
+		"CTRL-EVENT-CONNECTED - Connection to 02:00:00:00:01:00 completed [id=0 id_str=]")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := Connect(ctx, conn, NetworkConfig{SSID: "test", PSK: "password"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.NetworkID != 0 {
+		t.Errorf("wrong network id (got %d, expect 0)", res.NetworkID)
+	}
+	if bytes.Compare(res.BSSID, net.HardwareAddr{0x02, 0, 0, 0, 0x01, 0}) != 0 {
+		t.Errorf("wrong bssid (got %s)", res.BSSID)
+	}
+	if res.Frequency != 2412 {
+		t.Errorf("wrong frequency (got %d, expect 2412)", res.Frequency)
+	}
+
+	fs.expectCommands(
+		"ADD_NETWORK",
+		`SET_NETWORK 0 ssid "test"`,
+		`SET_NETWORK 0 psk "password"`,
+		"LIST_NETWORKS",
+		"SELECT_NETWORK 0",
+		"STATUS",
+	)
+}
+
+func TestConnectFailure(t *testing.T) {
+	tests := []struct {
+		event      string
+		reason     ConnectFailure
+		statusCode int
+	}{
+		{
+			event:  `CTRL-EVENT-SSID-TEMP-DISABLED id=0 ssid="test" auth_failures=1 duration=10 reason=WRONG_KEY`,
+			reason: ConnectWrongKey,
+		}, {
+			event:      "CTRL-EVENT-ASSOC-REJECT bssid=02:00:00:00:01:00 status_code=17",
+			reason:     ConnectAssocRejected,
+			statusCode: 17,
+		}, {
+			event:  "CTRL-EVENT-NETWORK-NOT-FOUND",
+			reason: ConnectNotFound,
+		}, {
+			event:  "CTRL-EVENT-EAP-FAILURE EAP authentication failed",
+			reason: ConnectEAPFailure,
+		},
+	}
+
+	for _, test := range tests {
+		fs, conn := connectServer(t, test.event)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := Connect(ctx, conn, NetworkConfig{SSID: "test"}, &ConnectOptions{RemoveOnFailure: true})
+		cancel()
+
+		cerr, ok := err.(*ConnectError)
+		if !ok {
+			t.Errorf("expected ConnectError, got %v", err)
+			continue
+		}
+		if cerr.Reason != test.reason {
+			t.Errorf("wrong reason (got %s, expect %s)", cerr.Reason, test.reason)
+		}
+		if cerr.StatusCode != test.statusCode {
+			t.Errorf("wrong status code (got %d, expect %d)", cerr.StatusCode, test.statusCode)
+		}
+		if cerr.NetworkID != -1 {
+			t.Errorf("network %d not removed", cerr.NetworkID)
+		}
+
+		fs.expectCommands(
+			"ADD_NETWORK",
+			`SET_NETWORK 0 ssid "test"`,
+			"SET_NETWORK 0 key_mgmt NONE",
+			"LIST_NETWORKS",
+			"SELECT_NETWORK 0",
+			"REMOVE_NETWORK 0",
+			"ENABLE_NETWORK 1",
+		)
+	}
+}
+
+func TestConnectTimeout(t *testing.T) {
+	fs, conn := connectServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := Connect(ctx, conn, NetworkConfig{SSID: "test"}, nil)
+	if cerr, ok := err.(*ConnectError); !ok || cerr.Reason != ConnectTimeout || cerr.NetworkID != 0 {
+		t.Errorf("expected timeout for network 0, got %v", err)
+	}
+
+	// The network is kept, and the previously enabled network is enabled
+	// again.
+	fs.expectCommands(
+		"ADD_NETWORK",
+		`SET_NETWORK 0 ssid "test"`,
+		"SET_NETWORK 0 key_mgmt NONE",
+		"LIST_NETWORKS",
+		"SELECT_NETWORK 0",
+		"ENABLE_NETWORK 1",
+	)
+}
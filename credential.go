@@ -0,0 +1,103 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CredentialField is the type of credential wpa_supplicant is asking for.
+type CredentialField string
+
+const (
+	CredentialIdentity      CredentialField = "IDENTITY"
+	CredentialPassword      CredentialField = "PASSWORD"
+	CredentialNewPassword   CredentialField = "NEW_PASSWORD"
+	CredentialPIN           CredentialField = "PIN"
+	CredentialOTP           CredentialField = "OTP"
+	CredentialPassphrase    CredentialField = "PASSPHRASE"
+	CredentialSIM           CredentialField = "SIM"
+	CredentialPSKPassphrase CredentialField = "PSK_PASSPHRASE"
+	CredentialExtCertCheck  CredentialField = "EXT_CERT_CHECK"
+)
+
+// CredentialRequest is a request from wpa_supplicant for a credential which
+// isn't in the network configuration, such as an EAP password or OTP.
+// Answer it using Conn.Respond().
+type CredentialRequest struct {
+	// Field is the credential being requested.
+	Field CredentialField
+
+	// NetworkID is the ID of the network which needs the credential.
+	NetworkID int
+
+	// Text is a human-readable prompt, e.g. "Password needed for SSID
+	// foo".
+	Text string
+}
+
+// ParseCredentialRequest returns the credential request contained in ev,
+// which wpa_supplicant sends as:
+//
+//	CTRL-REQ-<field>-<network id>:<text>
+//
+// The second return value is false if ev is not a credential request.
+func ParseCredentialRequest(ev WPAEvent) (*CredentialRequest, bool) {
+	if !strings.HasPrefix(ev.Line, "CTRL-REQ-") {
+		return nil, false
+	}
+
+	req, text := ev.Line[len("CTRL-REQ-"):], ""
+	if i := strings.IndexByte(req, ':'); i != -1 {
+		req, text = req[:i], req[i+1:]
+	}
+
+	// The field name may contain underscores, but not hyphens.
+	i := strings.LastIndexByte(req, '-')
+	if i == -1 {
+		return nil, false
+	}
+	id, err := strconv.Atoi(req[i+1:])
+	if err != nil {
+		return nil, false
+	}
+
+	return &CredentialRequest{
+		Field:     CredentialField(req[:i]),
+		NetworkID: id,
+		Text:      text,
+	}, true
+}
+
+// response returns the command which answers the request.
+func (req *CredentialRequest) response(value string) string {
+	return fmt.Sprintf("CTRL-RSP-%s-%d:%s", req.Field, req.NetworkID, value)
+}
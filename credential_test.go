@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"testing"
+	"time"
+)
+
+var parseCredentialRequestTests = []struct {
+	line   string
+	expect *CredentialRequest
+}{
+	{
+		line:   "CTRL-REQ-PASSWORD-1:Password needed for SSID corp",
+		expect: &CredentialRequest{Field: CredentialPassword, NetworkID: 1, Text: "Password needed for SSID corp"},
+	}, {
+		line:   "CTRL-REQ-PSK_PASSPHRASE-12:PSK or passphrase needed for SSID home",
+		expect: &CredentialRequest{Field: CredentialPSKPassphrase, NetworkID: 12, Text: "PSK or passphrase needed for SSID home"},
+	}, {
+		line:   "CTRL-REQ-OTP-0:Challenge 1235663 needed for SSID foo",
+		expect: &CredentialRequest{Field: CredentialOTP, NetworkID: 0, Text: "Challenge 1235663 needed for SSID foo"},
+	}, {
+		line: "CTRL-REQ-PASSWORD:no network id",
+	}, {
+		line: "CTRL-EVENT-CONNECTED - Connection to 02:00:00:00:01:00 completed [id=0 id_str=]",
+	},
+}
+
+func TestParseCredentialRequest(t *testing.T) {
+	for _, test := range parseCredentialRequestTests {
+		req, ok := ParseCredentialRequest(WPAEvent{Line: test.line})
+		if test.expect == nil {
+			if ok {
+				t.Errorf("unexpected credential request from %q: %+v", test.line, req)
+			}
+			continue
+		}
+
+		if !ok {
+			t.Errorf("failed to parse %q", test.line)
+			continue
+		}
+		if *req != *test.expect {
+			t.Errorf("got %+v, expected %+v", req, test.expect)
+		}
+	}
+}
+
+func TestRespond(t *testing.T) {
+	fs, conn := newFakeServer(t, nil)
+	fs.event("CTRL-REQ-PASSWORD-1:Password needed for SSID corp")
+
+	var ev WPAEvent
+	select {
+	case ev = <-conn.EventQueue():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	if ev.Event != "CTRL-REQ" {
+		t.Errorf("wrong event (got %s, expect CTRL-REQ)", ev.Event)
+	}
+
+	req, ok := ParseCredentialRequest(ev)
+	if !ok {
+		t.Fatalf("failed to parse %q", ev.Line)
+	}
+	if err := conn.Respond(req, "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	fs.expectCommands("CTRL-RSP-PASSWORD-1:secret")
+}
@@ -0,0 +1,561 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// DPPRole is the role of this device in DPP authentication.
+type DPPRole string
+
+const (
+	DPPConfigurator DPPRole = "configurator"
+	DPPEnrollee     DPPRole = "enrollee"
+	DPPEither       DPPRole = "either"
+)
+
+// DPPConfType is the kind of configuration a DPP configurator provisions.
+type DPPConfType string
+
+const (
+	DPPConfSTAPSK    DPPConfType = "sta-psk"
+	DPPConfSTASAE    DPPConfType = "sta-sae"
+	DPPConfSTAPSKSAE DPPConfType = "sta-psk-sae"
+	DPPConfSTADPP    DPPConfType = "sta-dpp"
+	DPPConfAPDPP     DPPConfType = "ap-dpp"
+)
+
+// DPP controls Wi-Fi Easy Connect (DPP) using a Conn.  Progress is reported
+// as DPP-* events on the Conn's EventQueue.
+type DPP struct {
+	c Conn
+}
+
+// NewDPP returns a DPP which sends commands using the specified Conn.
+func NewDPP(c Conn) *DPP {
+	return &DPP{c: c}
+}
+
+// id sends a command which is expected to return an ID.
+func (d *DPP) id(cmd string) (int, error) {
+	resp, err := d.c.Command(cmd)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := strconv.Atoi(strings.TrimSuffix(resp, "\n"))
+	if err != nil {
+		return -1, &ParseError{Line: resp, Err: err}
+	}
+	return id, nil
+}
+
+// DPPBootstrapOptions modifies the bootstrapping information generated by
+// DPP.BootstrapGen().
+type DPPBootstrapOptions struct {
+	// Channels are the operating class/channel pairs on which this
+	// device listens, e.g. "81/1".
+	Channels []string
+
+	// MAC is the address included in the bootstrapping URI.
+	MAC net.HardwareAddr
+
+	// Key is the hex-encoded DER private key to use.  If empty, a new
+	// key is generated.
+	Key string
+
+	// Info is free-form information included in the URI.
+	Info string
+}
+
+// BootstrapGen generates QR code bootstrapping information, returning its
+// ID.  Use BootstrapURI() to get the DPP: URI to display.
+func (d *DPP) BootstrapGen(opts *DPPBootstrapOptions) (int, error) {
+	cmd := "DPP_BOOTSTRAP_GEN type=qrcode"
+	if opts != nil {
+		if len(opts.Channels) > 0 {
+			cmd += " chan=" + strings.Join(opts.Channels, ",")
+		}
+		if opts.MAC != nil {
+			cmd += " mac=" + opts.MAC.String()
+		}
+		if opts.Key != "" {
+			cmd += " key=" + opts.Key
+		}
+		if opts.Info != "" {
+			cmd += " info=" + opts.Info
+		}
+	}
+	return d.id(cmd)
+}
+
+// BootstrapURI returns the DPP: URI of the bootstrapping information
+// identified by id.
+func (d *DPP) BootstrapURI(id int) (string, error) {
+	resp, err := d.c.Command(fmt.Sprintf("DPP_BOOTSTRAP_GET_URI %d", id))
+	if err != nil {
+		return "", err
+	}
+
+	resp = strings.TrimSuffix(resp, "\n")
+	if !strings.HasPrefix(resp, "DPP:") {
+		return "", &ParseError{Line: resp}
+	}
+	return resp, nil
+}
+
+// BootstrapRemove removes the bootstrapping information identified by id.
+func (d *DPP) BootstrapRemove(id int) error {
+	return runOK(d.c, fmt.Sprintf("DPP_BOOTSTRAP_REMOVE %d", id))
+}
+
+// QRCode adds a peer's bootstrapping information from the DPP: URI in its
+// QR code, returning the ID to pass to AuthInit().
+func (d *DPP) QRCode(uri string) (int, error) {
+	return d.id("DPP_QR_CODE " + uri)
+}
+
+// DPPConfigParams is the configuration a configurator provisions, used by
+// DPP.AuthInit() and DPP.ConfiguratorSign().
+type DPPConfigParams struct {
+	// Configurator is the ID returned by DPP.ConfiguratorAdd().  It is
+	// required for DPPConfSTADPP and DPPConfAPDPP.
+	Configurator int
+
+	// Conf is the kind of configuration to provision.
+	Conf DPPConfType
+
+	// SSID is the raw SSID of the network.
+	SSID string
+
+	// Passphrase or PSK is the network's pre-shared key, for
+	// DPPConfSTAPSK and similar.  PSK is 64 hex digits.
+	Passphrase string
+	PSK        string
+}
+
+// args formats the parameters as DPP command arguments.
+func (p *DPPConfigParams) args() string {
+	var args []string
+	if p.Conf != "" {
+		args = append(args, "conf="+string(p.Conf))
+	}
+	if p.SSID != "" {
+		args = append(args, "ssid="+hex.EncodeToString([]byte(p.SSID)))
+	}
+	if p.Passphrase != "" {
+		args = append(args, "pass="+hex.EncodeToString([]byte(p.Passphrase)))
+	}
+	if p.PSK != "" {
+		args = append(args, "psk="+p.PSK)
+	}
+	if p.Configurator != 0 {
+		args = append(args, fmt.Sprintf("configurator=%d", p.Configurator))
+	}
+	return strings.Join(args, " ")
+}
+
+// AuthInit starts DPP authentication with the peer whose bootstrapping
+// information was added by QRCode().  own is the ID of our bootstrapping
+// information for mutual authentication, or 0.  params is required if role
+// is DPPConfigurator.
+func (d *DPP) AuthInit(peer, own int, role DPPRole, params *DPPConfigParams) error {
+	cmd := fmt.Sprintf("DPP_AUTH_INIT peer=%d", peer)
+	if own != 0 {
+		cmd += fmt.Sprintf(" own=%d", own)
+	}
+	if role != "" {
+		cmd += " role=" + string(role)
+	}
+	if params != nil {
+		if args := params.args(); args != "" {
+			cmd += " " + args
+		}
+	}
+	return runOK(d.c, cmd)
+}
+
+// Listen waits on the specified frequency, in Mhz, for a peer to start DPP
+// authentication using our bootstrapping information.
+func (d *DPP) Listen(freq int, role DPPRole) error {
+	cmd := fmt.Sprintf("DPP_LISTEN %d", freq)
+	if role != "" {
+		cmd += " role=" + string(role)
+	}
+	return runOK(d.c, cmd)
+}
+
+// StopListen stops a Listen().
+func (d *DPP) StopListen() error {
+	return runOK(d.c, "DPP_STOP_LISTEN")
+}
+
+// ConfiguratorAdd adds a configurator, returning its ID.  curve is the
+// elliptic curve for its signing key, e.g. "P-256", or empty for the
+// default.
+func (d *DPP) ConfiguratorAdd(curve string) (int, error) {
+	cmd := "DPP_CONFIGURATOR_ADD"
+	if curve != "" {
+		cmd += " curve=" + curve
+	}
+	return d.id(cmd)
+}
+
+// ConfiguratorSign has a configurator provision this device, as if it
+// were an enrollee.  The configuration is reported as DPP-CONFOBJ-*,
+// DPP-CONNECTOR and related events.
+func (d *DPP) ConfiguratorSign(params *DPPConfigParams) error {
+	return runOK(d.c, "DPP_CONFIGURATOR_SIGN "+params.args())
+}
+
+// DPPAuthSuccess is a DPP-AUTH-SUCCESS event, sent when DPP authentication
+// completes.  It looks like:
+//
+//	DPP-AUTH-SUCCESS init=1
+type DPPAuthSuccess struct {
+	// Initiator is true if this device initiated authentication.
+	Initiator bool
+}
+
+// ParseDPPAuthSuccess returns the DPP-AUTH-SUCCESS event described by ev.
+// The second return value is false if ev isn't a DPP-AUTH-SUCCESS event.
+func ParseDPPAuthSuccess(ev WPAEvent) (*DPPAuthSuccess, bool) {
+	if ev.Event != "DPP-AUTH-SUCCESS" {
+		return nil, false
+	}
+	return &DPPAuthSuccess{Initiator: ev.Arguments["init"] == "1"}, true
+}
+
+// IsDPPConfReceived returns true if ev is a DPP-CONF-RECEIVED event, sent
+// when an enrollee receives its configuration.  The configuration follows
+// as DPP-CONFOBJ-* and related events.
+func IsDPPConfReceived(ev WPAEvent) bool {
+	return ev.Event == "DPP-CONF-RECEIVED"
+}
+
+// dppEventValue returns the rest of the line after a DPP event's name.
+func dppEventValue(ev WPAEvent, name string) (string, bool) {
+	if ev.Event != name {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(ev.Line, name)), true
+}
+
+// ParseDPPConfObjSSID returns the raw SSID in a DPP-CONFOBJ-SSID event,
+// which looks like:
+//
+//	DPP-CONFOBJ-SSID test
+//
+// The second return value is false if ev isn't a DPP-CONFOBJ-SSID event.
+func ParseDPPConfObjSSID(ev WPAEvent) (string, bool) {
+	value, ok := dppEventValue(ev, "DPP-CONFOBJ-SSID")
+	if !ok {
+		return "", false
+	}
+
+	ssid, err := printfDecode(value)
+	if err != nil {
+		return "", false
+	}
+	return ssid, true
+}
+
+// ParseDPPConnector returns the signed connector in a DPP-CONNECTOR event.
+// The second return value is false if ev isn't a DPP-CONNECTOR event.
+func ParseDPPConnector(ev WPAEvent) (string, bool) {
+	return dppEventValue(ev, "DPP-CONNECTOR")
+}
+
+// DPPConfig is the configuration received by a DPP enrollee.
+type DPPConfig struct {
+	// AKM is the network's authentication and key management, e.g.
+	// "psk", "sae", "psk+sae" or "dpp".
+	AKM string
+
+	// SSID is the raw SSID of the network.
+	SSID string
+
+	// Passphrase or PSK is the network's pre-shared key.  PSK is 64 hex
+	// digits.
+	Passphrase string
+	PSK        string
+
+	// Connector, CSignKey and NetAccessKey are the DPP credentials.
+	// The keys are hex-encoded.
+	Connector    string
+	CSignKey     string
+	NetAccessKey string
+}
+
+// update updates the configuration from a DPP-CONFOBJ-* or related event.
+func (cfg *DPPConfig) update(ev WPAEvent) {
+	value, _ := dppEventValue(ev, ev.Event)
+
+	switch ev.Event {
+	case "DPP-CONFOBJ-AKM":
+		cfg.AKM = value
+	case "DPP-CONFOBJ-SSID":
+		cfg.SSID, _ = ParseDPPConfObjSSID(ev)
+	case "DPP-CONFOBJ-PASS":
+		b, _ := hex.DecodeString(value)
+		cfg.Passphrase = string(b)
+	case "DPP-CONFOBJ-PSK":
+		cfg.PSK = value
+	case "DPP-CONNECTOR":
+		cfg.Connector = value
+	case "DPP-C-SIGN-KEY":
+		cfg.CSignKey = value
+	case "DPP-NET-ACCESS-KEY":
+		// The key may be followed by its expiry time.
+		cfg.NetAccessKey = strings.SplitN(value, " ", 2)[0]
+	}
+}
+
+// NetworkConfig returns the configuration of the network described by the
+// DPP configuration.
+func (cfg *DPPConfig) NetworkConfig() NetworkConfig {
+	nc := NetworkConfig{SSID: cfg.SSID}
+
+	var keyMgmt []string
+	for _, akm := range strings.Split(cfg.AKM, "+") {
+		switch akm {
+		case "psk":
+			keyMgmt = append(keyMgmt, "WPA-PSK")
+			nc.PSK = cfg.PSK
+			if cfg.Passphrase != "" {
+				nc.PSK = cfg.Passphrase
+			}
+		case "sae":
+			keyMgmt = append(keyMgmt, "SAE")
+			nc.SAEPassword = cfg.Passphrase
+			nc.IEEE80211w = 1
+		case "dpp":
+			keyMgmt = append(keyMgmt, "DPP")
+			nc.DPPConnector = cfg.Connector
+			nc.DPPCSign = cfg.CSignKey
+			nc.DPPNetAccessKey = cfg.NetAccessKey
+			nc.IEEE80211w = 1
+		}
+	}
+	nc.KeyMgmt = strings.Join(keyMgmt, " ")
+
+	if nc.IEEE80211w != 0 && len(keyMgmt) == 1 {
+		// SAE and DPP require protected management frames.
+		nc.IEEE80211w = 2
+	}
+	return nc
+}
+
+// DPPFailure is the reason DPP onboarding failed.
+type DPPFailure int
+
+const (
+	// DPPTimeout means the context expired before onboarding completed.
+	DPPTimeout DPPFailure = iota
+
+	// DPPAuthFailed means DPP authentication failed.
+	DPPAuthFailed
+
+	// DPPNotCompatible means the peer doesn't support the required role.
+	DPPNotCompatible
+
+	// DPPConfFailed means the configurator didn't provision us.
+	DPPConfFailed
+
+	// DPPFailed means the DPP protocol failed for another reason.
+	DPPFailed
+)
+
+func (f DPPFailure) String() string {
+	switch f {
+	case DPPTimeout:
+		return "timed out"
+	case DPPAuthFailed:
+		return "authentication failed"
+	case DPPNotCompatible:
+		return "peer not compatible"
+	case DPPConfFailed:
+		return "configuration failed"
+	case DPPFailed:
+		return "failed"
+	}
+	return fmt.Sprintf("DPPFailure(%d)", int(f))
+}
+
+// DPPError is returned by DPPEnroll() when onboarding fails.
+type DPPError struct {
+	// Reason is why onboarding failed.
+	Reason DPPFailure
+
+	// Line is the event from wpa_supplicant which reported the failure,
+	// if any.
+	Line string
+
+	// Err is any nested error, such as the context's error on timeout.
+	Err error
+}
+
+func (err *DPPError) Error() string {
+	msg := "DPP onboarding " + err.Reason.String()
+
+	if err.Err != nil {
+		msg += ": " + err.Err.Error()
+	}
+
+	return msg
+}
+
+// DPPEnrollOptions modifies the behavior of DPPEnroll().
+type DPPEnrollOptions struct {
+	// PeerURI is the DPP: URI of the configurator.  If set, we initiate
+	// authentication with it; otherwise we listen for the configurator
+	// to scan our QR code.
+	PeerURI string
+
+	// Frequency is the frequency, in Mhz, to listen on.  It defaults to
+	// 2437 (channel 6).
+	Frequency int
+
+	// Connect has wpa_supplicant connect to the network once it is
+	// provisioned.
+	Connect bool
+}
+
+// DPPResult describes a successful DPP onboarding.
+type DPPResult struct {
+	// NetworkID is the ID of the network wpa_supplicant added using the
+	// received configuration.
+	NetworkID int
+
+	// Config is the received configuration.
+	Config DPPConfig
+}
+
+// DPPEnroll onboards this device as a DPP enrollee using the bootstrapping
+// information identified by bootstrapID, and waits until wpa_supplicant has
+// received its configuration and added a network for it.  Failures are
+// returned as a *DPPError.  Use the context to limit how long to wait.
+//
+// DPPEnroll sets dpp_config_processing so wpa_supplicant adds the network,
+// and connects to it if opts.Connect is set, restoring the previous value
+// before it returns.  It consumes the Conn's EventQueue while it runs, so
+// callers should not be reading events from it at the same time.
+func DPPEnroll(ctx context.Context, c Conn, bootstrapID int, opts *DPPEnrollOptions) (*DPPResult, error) {
+	if opts == nil {
+		opts = &DPPEnrollOptions{}
+	}
+	d := NewDPP(c)
+
+	events := newEventPump(c)
+	defer events.stop()
+
+	// Restore the previous dpp_config_processing when done, so that
+	// enrolling doesn't change how later configurations are handled.
+	resp, err := d.c.Command("GET dpp_config_processing")
+	if err != nil {
+		return nil, err
+	}
+	resp = strings.TrimSpace(resp)
+	old, err := strconv.Atoi(resp)
+	if err != nil {
+		return nil, &ParseError{Line: resp, Err: err}
+	}
+
+	processing := 1
+	if opts.Connect {
+		processing = 2
+	}
+	if err = runOK(d.c, fmt.Sprintf("SET dpp_config_processing %d", processing)); err != nil {
+		return nil, err
+	}
+	defer runOK(d.c, fmt.Sprintf("SET dpp_config_processing %d", old))
+
+	if opts.PeerURI != "" {
+		peer, err := d.QRCode(opts.PeerURI)
+		if err != nil {
+			return nil, err
+		}
+		if err = d.AuthInit(peer, bootstrapID, DPPEnrollee, nil); err != nil {
+			return nil, err
+		}
+	} else {
+		freq := opts.Frequency
+		if freq == 0 {
+			freq = 2437
+		}
+		if err := d.Listen(freq, DPPEnrollee); err != nil {
+			return nil, err
+		}
+		defer d.StopListen()
+	}
+
+	res := &DPPResult{NetworkID: -1}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, &DPPError{
+				Reason: DPPTimeout,
+				Err:    ctx.Err(),
+			}
+
+		case ev := <-events.C:
+			switch ev.Event {
+			case "DPP-NETWORK-ID":
+				value, _ := dppEventValue(ev, ev.Event)
+				id, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, &ParseError{Line: ev.Line, Err: err}
+				}
+				res.NetworkID = id
+				return res, nil
+
+			case "DPP-AUTH-INIT-FAILED":
+				return nil, &DPPError{Reason: DPPAuthFailed, Line: ev.Line}
+
+			case "DPP-NOT-COMPATIBLE":
+				return nil, &DPPError{Reason: DPPNotCompatible, Line: ev.Line}
+
+			case "DPP-CONF-FAILED":
+				return nil, &DPPError{Reason: DPPConfFailed, Line: ev.Line}
+
+			case "DPP-FAIL":
+				return nil, &DPPError{Reason: DPPFailed, Line: ev.Line}
+
+			default:
+				res.Config.update(ev)
+			}
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestDPPCommands(t *testing.T) {
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch cmd {
+		case "DPP_BOOTSTRAP_GEN type=qrcode",
+			"DPP_BOOTSTRAP_GEN type=qrcode chan=81/1,81/6 mac=02:00:00:00:01:00 info=test":
+			return "1\n"
+		case "DPP_BOOTSTRAP_GET_URI 1":
+			return "DPP:C:81/1;K:MDkw;;\n"
+		case "DPP_BOOTSTRAP_GET_URI 2":
+			return "FAIL\n"
+		case "DPP_QR_CODE DPP:K:MDkw;;", "DPP_CONFIGURATOR_ADD", "DPP_CONFIGURATOR_ADD curve=P-384":
+			return "2\n"
+		}
+		return ""
+	})
+	d := NewDPP(conn)
+
+	if id, err := d.BootstrapGen(nil); err != nil || id != 1 {
+		t.Errorf("BootstrapGen: got %d, %v", id, err)
+	}
+	if _, err := d.BootstrapGen(&DPPBootstrapOptions{
+		Channels: []string{"81/1", "81/6"},
+		MAC:      net.HardwareAddr{2, 0, 0, 0, 1, 0},
+		Info:     "test",
+	}); err != nil {
+		t.Error(err)
+	}
+	if uri, err := d.BootstrapURI(1); err != nil || uri != "DPP:C:81/1;K:MDkw;;" {
+		t.Errorf("BootstrapURI: got %q, %v", uri, err)
+	}
+	if _, err := d.BootstrapURI(2); err == nil {
+		t.Error("BootstrapURI: expected error")
+	}
+	if err := d.BootstrapRemove(1); err != nil {
+		t.Error(err)
+	}
+	if id, err := d.QRCode("DPP:K:MDkw;;"); err != nil || id != 2 {
+		t.Errorf("QRCode: got %d, %v", id, err)
+	}
+	if _, err := d.ConfiguratorAdd(""); err != nil {
+		t.Error(err)
+	}
+	if _, err := d.ConfiguratorAdd("P-384"); err != nil {
+		t.Error(err)
+	}
+	if err := d.AuthInit(2, 0, DPPConfigurator, &DPPConfigParams{Conf: DPPConfSTAPSK, SSID: "test", Passphrase: "secret"}); err != nil {
+		t.Error(err)
+	}
+	if err := d.AuthInit(2, 1, DPPEnrollee, nil); err != nil {
+		t.Error(err)
+	}
+	if err := d.Listen(2412, ""); err != nil {
+		t.Error(err)
+	}
+	if err := d.StopListen(); err != nil {
+		t.Error(err)
+	}
+	if err := d.ConfiguratorSign(&DPPConfigParams{Configurator: 2, Conf: DPPConfSTADPP, SSID: "test"}); err != nil {
+		t.Error(err)
+	}
+
+	fs.expectCommands(
+		"DPP_BOOTSTRAP_GEN type=qrcode",
+		"DPP_BOOTSTRAP_GEN type=qrcode chan=81/1,81/6 mac=02:00:00:00:01:00 info=test",
+		"DPP_BOOTSTRAP_GET_URI 1",
+		"DPP_BOOTSTRAP_GET_URI 2",
+		"DPP_BOOTSTRAP_REMOVE 1",
+		"DPP_QR_CODE DPP:K:MDkw;;",
+		"DPP_CONFIGURATOR_ADD",
+		"DPP_CONFIGURATOR_ADD curve=P-384",
+		"DPP_AUTH_INIT peer=2 role=configurator conf=sta-psk ssid=74657374 pass=736563726574",
+		"DPP_AUTH_INIT peer=2 own=1 role=enrollee",
+		"DPP_LISTEN 2412",
+		"DPP_STOP_LISTEN",
+		"DPP_CONFIGURATOR_SIGN conf=sta-dpp ssid=74657374 configurator=2",
+	)
+}
+
+func TestParseDPPEvents(t *testing.T) {
+	if auth, ok := ParseDPPAuthSuccess(WPAEvent{
+		Event:     "DPP-AUTH-SUCCESS",
+		Arguments: map[string]string{"init": "1"},
+		Line:      "DPP-AUTH-SUCCESS init=1",
+	}); !ok || !auth.Initiator {
+		t.Errorf("DPP-AUTH-SUCCESS: got %+v", auth)
+	}
+
+	if !IsDPPConfReceived(WPAEvent{Event: "DPP-CONF-RECEIVED", Line: "DPP-CONF-RECEIVED"}) {
+		t.Error("DPP-CONF-RECEIVED not recognized")
+	}
+
+	if ssid, ok := ParseDPPConfObjSSID(WPAEvent{
+		Event: "DPP-CONFOBJ-SSID",
+		Line:  `DPP-CONFOBJ-SSID my net\x00`,
+	}); !ok || ssid != "my net\x00" {
+		t.Errorf("DPP-CONFOBJ-SSID: got %q", ssid)
+	}
+
+	if connector, ok := ParseDPPConnector(WPAEvent{
+		Event: "DPP-CONNECTOR",
+		Line:  "DPP-CONNECTOR eyJ0eXAiOiJkcHBDb24ifQ.eyJ9.sig",
+	}); !ok || connector != "eyJ0eXAiOiJkcHBDb24ifQ.eyJ9.sig" {
+		t.Errorf("DPP-CONNECTOR: got %q", connector)
+	}
+
+	if _, ok := ParseDPPConnector(WPAEvent{Event: "DPP-CONFOBJ-SSID"}); ok {
+		t.Error("parsed DPP-CONFOBJ-SSID as DPP-CONNECTOR")
+	}
+}
+
+func TestDPPConfigNetworkConfig(t *testing.T) {
+	tests := []struct {
+		cfg    DPPConfig
+		expect NetworkConfig
+	}{
+		{
+			cfg:    DPPConfig{AKM: "psk", SSID: "test", Passphrase: "secret123"},
+			expect: NetworkConfig{SSID: "test", KeyMgmt: "WPA-PSK", PSK: "secret123"},
+		}, {
+			cfg:    DPPConfig{AKM: "psk+sae", SSID: "test", Passphrase: "secret123"},
+			expect: NetworkConfig{SSID: "test", KeyMgmt: "WPA-PSK SAE", PSK: "secret123", SAEPassword: "secret123", IEEE80211w: 1},
+		}, {
+			cfg: DPPConfig{AKM: "dpp", SSID: "test", Connector: "c", CSignKey: "30", NetAccessKey: "31"},
+			expect: NetworkConfig{SSID: "test", KeyMgmt: "DPP", IEEE80211w: 2,
+				DPPConnector: "c", DPPCSign: "30", DPPNetAccessKey: "31"},
+		},
+	}
+
+	for _, test := range tests {
+		if nc := test.cfg.NetworkConfig(); nc != test.expect {
+			t.Errorf("%s: got %+v", test.cfg.AKM, nc)
+		}
+	}
+}
+
+func TestDPPEnroll(t *testing.T) {
+	var fs *fakeServer
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		if cmd == "GET dpp_config_processing" {
+			return "0"
+		}
+		if cmd == "DPP_LISTEN 2437 role=enrollee" {
+			go func() {
+				fs.event("DPP-AUTH-SUCCESS init=0")
+				fs.event("DPP-CONF-RECEIVED")
+				fs.event("DPP-CONFOBJ-AKM psk")
+				fs.event("DPP-CONFOBJ-SSID test")
+				fs.event("DPP-CONFOBJ-PASS 736563726574313233")
+				fs.event("DPP-NETWORK-ID 3")
+			}()
+		}
+		return ""
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := DPPEnroll(ctx, conn, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NetworkID != 3 || res.Config.AKM != "psk" || res.Config.SSID != "test" || res.Config.Passphrase != "secret123" {
+		t.Errorf("got %+v", res)
+	}
+
+	fs.expectCommands(
+		"GET dpp_config_processing",
+		"SET dpp_config_processing 1",
+		"DPP_LISTEN 2437 role=enrollee",
+		"DPP_STOP_LISTEN",
+		"SET dpp_config_processing 0",
+	)
+}
+
+func TestDPPEnrollFailure(t *testing.T) {
+	var fs *fakeServer
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch cmd {
+		case "GET dpp_config_processing":
+			return "1"
+		case "DPP_QR_CODE DPP:K:MDkw;;":
+			return "2\n"
+		case "DPP_AUTH_INIT peer=2 own=1 role=enrollee":
+			go fs.event("DPP-AUTH-INIT-FAILED")
+		}
+		return ""
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := DPPEnroll(ctx, conn, 1, &DPPEnrollOptions{PeerURI: "DPP:K:MDkw;;", Connect: true})
+	if derr, ok := err.(*DPPError); !ok || derr.Reason != DPPAuthFailed {
+		t.Errorf("expected authentication failure, got %v", err)
+	}
+
+	fs.expectCommands(
+		"GET dpp_config_processing",
+		"SET dpp_config_processing 2",
+		"DPP_QR_CODE DPP:K:MDkw;;",
+		"DPP_AUTH_INIT peer=2 own=1 role=enrollee",
+		"SET dpp_config_processing 1",
+	)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// EAPMethod is an EAP method name, as used in the eap network variable.
+// Multiple methods may be separated by spaces.
+type EAPMethod string
+
+const (
+	EAP_TLS  EAPMethod = "TLS"
+	EAP_PEAP EAPMethod = "PEAP"
+	EAP_TTLS EAPMethod = "TTLS"
+	EAP_PWD  EAPMethod = "PWD"
+	EAP_FAST EAPMethod = "FAST"
+	EAP_SIM  EAPMethod = "SIM"
+	EAP_AKA  EAPMethod = "AKA"
+	EAP_LEAP EAPMethod = "LEAP"
+)
+
+// has returns true if m includes the specified method.
+func (m EAPMethod) has(method EAPMethod) bool {
+	for _, f := range strings.Fields(string(m)) {
+		if EAPMethod(f) == method {
+			return true
+		}
+	}
+	return false
+}
+
+// EAPConfig is the IEEE 802.1X/EAP part of a network configuration.  It is
+// embedded in NetworkConfig.
+type EAPConfig struct {
+	// EAP is the list of accepted EAP methods, e.g. EAP_PEAP.
+	EAP EAPMethod `wpa:"eap,raw"`
+
+	// Identity and AnonymousIdentity are the EAP identity and the
+	// unencrypted identity used with tunneled EAP methods.
+	Identity          string `wpa:"identity"`
+	AnonymousIdentity string `wpa:"anonymous_identity"`
+
+	// Password is the EAP password, or "hash:" followed by the 32 hex
+	// digit NtPasswordHash for MSCHAPv2.
+	Password string `wpa:"password,password"`
+
+	// CACert, ClientCert, PrivateKey and PrivateKeyPasswd are the
+	// certificate and private key files used to authenticate the server
+	// and ourselves.  Certificates and keys loaded with SetBlob() can be
+	// referenced using BlobURL().
+	CACert           string `wpa:"ca_cert"`
+	CAPath           string `wpa:"ca_path"`
+	ClientCert       string `wpa:"client_cert"`
+	PrivateKey       string `wpa:"private_key"`
+	PrivateKeyPasswd string `wpa:"private_key_passwd,secret"`
+
+	// CACert2, ClientCert2, PrivateKey2 and PrivateKey2Passwd are used
+	// for inner (phase 2) TLS authentication.
+	CACert2           string `wpa:"ca_cert2"`
+	ClientCert2       string `wpa:"client_cert2"`
+	PrivateKey2       string `wpa:"private_key2"`
+	PrivateKey2Passwd string `wpa:"private_key2_passwd,secret"`
+
+	// Phase1 and Phase2 are parameters for the outer and inner
+	// authentication of tunneled EAP methods, e.g. "auth=MSCHAPV2".
+	Phase1 string `wpa:"phase1"`
+	Phase2 string `wpa:"phase2"`
+
+	// SubjectMatch, AltSubjectMatch, DomainSuffixMatch and DomainMatch
+	// constrain the server certificate.
+	SubjectMatch      string `wpa:"subject_match"`
+	AltSubjectMatch   string `wpa:"altsubject_match"`
+	DomainSuffixMatch string `wpa:"domain_suffix_match"`
+	DomainMatch       string `wpa:"domain_match"`
+
+	// EAPOLFlags are the dynamic WEP key flags for IEEE 802.1X non-WPA
+	// mode.
+	EAPOLFlags int `wpa:"eapol_flags"`
+
+	// FragmentSize is the maximum EAP fragment size.
+	FragmentSize int `wpa:"fragment_size"`
+
+	// OCSP configures certificate status checking: 0 to disable, 1 to
+	// try, 2 to require, and 3 to require for all certificates.
+	OCSP int `wpa:"ocsp"`
+}
+
+// ConfigError is returned when a network configuration is invalid.
+type ConfigError struct {
+	// Variable is the name of the network variable which is missing or
+	// invalid.
+	Variable string
+
+	// Reason explains what is wrong with it.
+	Reason string
+}
+
+func (err *ConfigError) Error() string {
+	return fmt.Sprintf("invalid network configuration: %s %s", err.Variable, err.Reason)
+}
+
+// Validate checks that the EAP configuration has everything required by
+// its EAP methods.  An empty configuration is valid.
+func (e *EAPConfig) Validate() error {
+	if e.EAP == "" {
+		return nil
+	}
+
+	if e.EAP.has(EAP_TLS) {
+		if e.ClientCert == "" {
+			return &ConfigError{"client_cert", "is required for EAP-TLS"}
+		}
+		if e.PrivateKey == "" {
+			return &ConfigError{"private_key", "is required for EAP-TLS"}
+		}
+	}
+
+	if e.EAP.has(EAP_PEAP) || e.EAP.has(EAP_TTLS) || e.EAP.has(EAP_PWD) || e.EAP.has(EAP_LEAP) {
+		if e.Identity == "" {
+			return &ConfigError{"identity", fmt.Sprintf("is required for EAP-%s", e.EAP)}
+		}
+	}
+
+	if e.EAP.has(EAP_TTLS) && e.Phase2 == "" {
+		return &ConfigError{"phase2", "is required for EAP-TTLS"}
+	}
+
+	if strings.HasPrefix(e.Password, "hash:") {
+		if h := strings.TrimPrefix(e.Password, "hash:"); len(h) != 32 || !isHex(h) {
+			return &ConfigError{"password", "hash must be 32 hex digits"}
+		}
+	}
+
+	return nil
+}
+
+// BlobURL returns the value used in a certificate or key variable to refer
+// to a blob loaded with SetBlob().
+func BlobURL(name string) string {
+	return "blob://" + name
+}
+
+// blobCommand returns the command which loads a blob.
+func blobCommand(name string, data []byte) string {
+	return fmt.Sprintf("SET blob %s %s", name, hex.EncodeToString(data))
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"testing"
+)
+
+var validateEAPTests = []struct {
+	cfg      EAPConfig
+	variable string
+}{
+	{
+		cfg: EAPConfig{},
+	}, {
+		cfg: EAPConfig{
+			EAP:      EAP_PEAP,
+			Identity: "user",
+			Password: "secret",
+			Phase2:   "auth=MSCHAPV2",
+		},
+	}, {
+		cfg: EAPConfig{
+			EAP:      EAP_PEAP,
+			Password: "secret",
+		},
+		variable: "identity",
+	}, {
+		cfg: EAPConfig{
+			EAP:      EAP_TTLS,
+			Identity: "user",
+		},
+		variable: "phase2",
+	}, {
+		cfg: EAPConfig{
+			EAP:        EAP_TLS,
+			Identity:   "user",
+			PrivateKey: "/etc/cert/user.key",
+		},
+		variable: "client_cert",
+	}, {
+		cfg: EAPConfig{
+			EAP:        EAP_TLS,
+			Identity:   "user",
+			ClientCert: BlobURL("user-cert"),
+			PrivateKey: BlobURL("user-key"),
+		},
+	}, {
+		cfg: EAPConfig{
+			EAP:      "PEAP TTLS",
+			Identity: "user",
+		},
+		variable: "phase2",
+	}, {
+		cfg: EAPConfig{
+			EAP:      EAP_PEAP,
+			Identity: "user",
+			Password: "hash:1234",
+		},
+		variable: "password",
+	},
+}
+
+func TestValidateEAP(t *testing.T) {
+	for _, test := range validateEAPTests {
+		err := test.cfg.Validate()
+		if test.variable == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %+v: %v", test.cfg, err)
+			}
+			continue
+		}
+
+		if cerr, ok := err.(*ConfigError); !ok || cerr.Variable != test.variable {
+			t.Errorf("expected error for %s in %+v, got %v", test.variable, test.cfg, err)
+		}
+	}
+}
+
+func TestApplyEAPNetwork(t *testing.T) {
+	fs, conn := newFakeServer(t, nil)
+
+	err := conn.ApplyNetwork(0, NetworkConfig{
+		SSID:    "corp",
+		KeyMgmt: "WPA-EAP",
+		EAPConfig: EAPConfig{
+			EAP:               EAP_PEAP,
+			Identity:          "user@example.com",
+			AnonymousIdentity: "anonymous@example.com",
+			Password:          "hash:0123456789abcdef0123456789abcdef",
+			CACert:            BlobURL("corp-ca"),
+			Phase2:            "auth=MSCHAPV2",
+			DomainSuffixMatch: "radius.example.com",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs.expectCommands(
+		`SET_NETWORK 0 ssid "corp"`,
+		"SET_NETWORK 0 key_mgmt WPA-EAP",
+		"SET_NETWORK 0 eap PEAP",
+		`SET_NETWORK 0 identity "user@example.com"`,
+		`SET_NETWORK 0 anonymous_identity "anonymous@example.com"`,
+		"SET_NETWORK 0 password hash:0123456789abcdef0123456789abcdef",
+		`SET_NETWORK 0 ca_cert "blob://corp-ca"`,
+		`SET_NETWORK 0 phase2 "auth=MSCHAPV2"`,
+		`SET_NETWORK 0 domain_suffix_match "radius.example.com"`,
+	)
+}
+
+func TestApplyInvalidEAPNetwork(t *testing.T) {
+	fs, conn := newFakeServer(t, nil)
+
+	err := conn.ApplyNetwork(0, NetworkConfig{
+		SSID:      "corp",
+		EAPConfig: EAPConfig{EAP: EAP_TLS, Identity: "user"},
+	})
+	if _, ok := err.(*ConfigError); !ok {
+		t.Errorf("expected ConfigError, got %v", err)
+	}
+
+	// Nothing should have been sent to wpa_supplicant.
+	fs.expectCommands()
+}
+
+func TestSetBlob(t *testing.T) {
+	fs, conn := newFakeServer(t, nil)
+
+	if err := conn.SetBlob("corp-ca", []byte{0x30, 0x82, 0x01, 0x0a}); err != nil {
+		t.Fatal(err)
+	}
+
+	fs.expectCommands("SET blob corp-ca 3082010a")
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// eventPump drains a Conn's EventQueue into a buffered channel while a
+// multi-step operation is in progress.  This keeps unsolicited messages
+// from blocking the responses to the commands the operation issues.
+type eventPump struct {
+	C    chan WPAEvent
+	done chan struct{}
+}
+
+func newEventPump(c Conn) *eventPump {
+	p := &eventPump{
+		C:    make(chan WPAEvent, 64),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		for {
+			select {
+			case ev := <-c.EventQueue():
+				select {
+				case p.C <- ev:
+				case <-p.done:
+					return
+				}
+			case <-p.done:
+				return
+			}
+		}
+	}()
+
+	return p
+}
+
+// stop stops draining the EventQueue.
+func (p *eventPump) stop() {
+	close(p.done)
+}
+
+// EventBroadcaster distributes the events from a Conn's EventQueue to any
+// number of subscribers, such as the clients of a server which wraps the
+// Conn.
+type EventBroadcaster struct {
+	conn Conn
+
+	mu   sync.Mutex
+	subs map[chan WPAEvent]struct{}
+}
+
+// NewEventBroadcaster returns an EventBroadcaster for the specified Conn.
+// Run() must be called to start distributing events.
+func NewEventBroadcaster(c Conn) *EventBroadcaster {
+	return &EventBroadcaster{
+		conn: c,
+		subs: make(map[chan WPAEvent]struct{}),
+	}
+}
+
+// Run distributes events until the context is done.  Callers should not be
+// reading events from the EventQueue at the same time.
+func (b *EventBroadcaster) Run(ctx context.Context) error {
+	for {
+		select {
+		case ev := <-b.conn.EventQueue():
+			b.mu.Lock()
+			for ch := range b.subs {
+				// Drop events for subscribers which aren't
+				// keeping up, rather than blocking the
+				// responses to everyone's commands.
+				select {
+				case ch <- ev:
+				default:
+				}
+			}
+			b.mu.Unlock()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Subscribe returns a Conn which sends commands to the underlying Conn, and
+// whose EventQueue receives a copy of each event until Unsubscribe() is
+// called.  It can be passed to helpers such as Connect() which wait for
+// events.
+func (b *EventBroadcaster) Subscribe() Conn {
+	sub := subscribedConn{b.conn, make(chan WPAEvent, 64)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subs[sub.events] = struct{}{}
+	return sub
+}
+
+// Unsubscribe stops sending events to a Conn returned by Subscribe().
+func (b *EventBroadcaster) Unsubscribe(c Conn) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subs, c.EventQueue())
+}
+
+// subscribedConn is a Conn whose EventQueue is an EventBroadcaster
+// subscription.
+type subscribedConn struct {
+	Conn
+	events chan WPAEvent
+}
+
+func (c subscribedConn) EventQueue() chan WPAEvent {
+	return c.events
+}
+
+// secretEvents are the events whose whole payload is a secret, such as a
+// received passphrase or private key.
+var secretEvents = map[string]bool{
+	"DPP-CONFOBJ-PASS":   true,
+	"DPP-CONFOBJ-PSK":    true,
+	"DPP-CONNECTOR":      true,
+	"DPP-NET-ACCESS-KEY": true,
+	"WPS-CRED-RECEIVED":  true,
+}
+
+var (
+	p2pPassphraseRegexp = regexp.MustCompile(` passphrase=".*"( go_dev_addr=|$)`)
+	p2pPSKRegexp        = regexp.MustCompile(` psk=[0-9A-Fa-f]*`)
+)
+
+// Redacted returns a copy of the event with any secrets removed, so that
+// it can be passed on to clients, such as by a server which wraps a Conn.
+// The payload of events which carry credentials, such as DPP-CONFOBJ-PASS
+// and WPS-CRED-RECEIVED, is removed, as are the passphrase and PSK of
+// P2P-GROUP-STARTED and the prompt text of credential requests.
+func (ev WPAEvent) Redacted() WPAEvent {
+	name := ev.Line
+	if i := strings.IndexAny(name, " :"); i != -1 {
+		name = name[:i]
+	}
+
+	switch {
+	case secretEvents[ev.Event], ev.Event == "CTRL-REQ":
+		// For credential requests, the name includes the field and
+		// network ID, so clients can still respond.
+		ev.Line, ev.Arguments = name, map[string]string{}
+
+	case ev.Event == "P2P-GROUP-STARTED":
+		ev.Line = p2pPassphraseRegexp.ReplaceAllString(ev.Line, "$1")
+		ev.Line = p2pPSKRegexp.ReplaceAllString(ev.Line, "")
+
+		args := make(map[string]string, len(ev.Arguments))
+		for k, v := range ev.Arguments {
+			if k != "passphrase" && k != "psk" {
+				args[k] = v
+			}
+		}
+		ev.Arguments = args
+	}
+	return ev
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventBroadcaster(t *testing.T) {
+	fs, conn := newFakeServer(t, nil)
+
+	b := NewEventBroadcaster(conn)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go b.Run(ctx)
+
+	sub1, sub2 := b.Subscribe(), b.Subscribe()
+	fs.event("CTRL-EVENT-SCAN-STARTED ")
+
+	for i, sub := range []Conn{sub1, sub2} {
+		select {
+		case ev := <-sub.EventQueue():
+			if ev.Event != "SCAN-STARTED" {
+				t.Errorf("subscriber %d: got event %q", i, ev.Event)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("subscriber %d: no event", i)
+		}
+	}
+
+	// Subscriptions can still send commands.
+	if err := sub1.Scan(); err != nil {
+		t.Error(err)
+	}
+
+	b.Unsubscribe(sub1)
+	fs.event("CTRL-EVENT-SCAN-RESULTS ")
+	select {
+	case <-sub2.EventQueue():
+	case <-time.After(time.Second):
+		t.Fatal("no event after unsubscribing another subscriber")
+	}
+	select {
+	case ev := <-sub1.EventQueue():
+		t.Errorf("got event %q after unsubscribing", ev.Event)
+	default:
+	}
+}
+
+func TestWPAEventRedacted(t *testing.T) {
+	for _, test := range []struct {
+		line, expect string
+	}{
+		{
+			`P2P-GROUP-STARTED p2p-wlan0-0 GO ssid="DIRECT-ab" freq=2412 passphrase="a "quoted" secret" go_dev_addr=02:00:00:00:00:01 [PERSISTENT]`,
+			`P2P-GROUP-STARTED p2p-wlan0-0 GO ssid="DIRECT-ab" freq=2412 go_dev_addr=02:00:00:00:00:01 [PERSISTENT]`,
+		},
+		{
+			`P2P-GROUP-STARTED p2p-wlan0-0 client ssid="DIRECT-ab" freq=2412 psk=0123456789abcdef go_dev_addr=02:00:00:00:00:01`,
+			`P2P-GROUP-STARTED p2p-wlan0-0 client ssid="DIRECT-ab" freq=2412 go_dev_addr=02:00:00:00:00:01`,
+		},
+		{"DPP-CONFOBJ-PASS 736563726574313233", "DPP-CONFOBJ-PASS"},
+		{"DPP-NET-ACCESS-KEY 30770201 0", "DPP-NET-ACCESS-KEY"},
+		{"WPS-CRED-RECEIVED 100e00371026", "WPS-CRED-RECEIVED"},
+		{"CTRL-REQ-OTP-1:Challenge 1234 needed for SSID foo", "CTRL-REQ-OTP-1"},
+		{"CTRL-EVENT-DISCONNECTED bssid=02:00:00:00:01:00 reason=3", "CTRL-EVENT-DISCONNECTED bssid=02:00:00:00:01:00 reason=3"},
+	} {
+		fs, conn := newFakeServer(t, nil)
+		fs.event(test.line)
+
+		select {
+		case ev := <-conn.EventQueue():
+			r := ev.Redacted()
+			if r.Line != test.expect {
+				t.Errorf("got %q, expected %q", r.Line, test.expect)
+			}
+			for k, v := range r.Arguments {
+				if !strings.Contains(r.Line, k+"="+v) {
+					t.Errorf("%s: argument %s=%s not redacted", test.line, k, v)
+				}
+			}
+			if ev.Line != test.line || len(ev.Arguments) < len(r.Arguments) {
+				t.Errorf("original event modified: %+v", ev)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s: no event", test.line)
+		}
+	}
+}
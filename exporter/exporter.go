@@ -0,0 +1,318 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package exporter exports the state of wpa_supplicant as Prometheus
+// metrics.
+//
+// An Exporter periodically polls a wpasupplicant.Conn for its status, link
+// quality and scan results, counts the events it sends, and serves the
+// result in the Prometheus text exposition format.
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"pifke.org/wpasupplicant"
+)
+
+// Exporter collects metrics from a wpasupplicant.Conn.  It implements
+// http.Handler.
+type Exporter struct {
+	conn  wpasupplicant.Conn
+	iface string
+
+	mu      sync.Mutex
+	up      bool
+	status  wpasupplicant.StatusResult
+	signal  wpasupplicant.SignalPollResult
+	packets wpasupplicant.PacketCountResult
+	scan    []wpasupplicant.ScanResult
+
+	// counters are keyed by metric name, then by label values.
+	counters map[string]map[string]float64
+}
+
+// New returns an Exporter for the specified interface.  The interface name
+// is only used as a label.
+func New(c wpasupplicant.Conn, iface string) *Exporter {
+	return &Exporter{
+		conn:     c,
+		iface:    iface,
+		counters: make(map[string]map[string]float64),
+	}
+}
+
+// Run polls wpa_supplicant at the specified interval and counts events from
+// the Conn's EventQueue, until the context is done.  Callers should not be
+// reading events from the EventQueue at the same time.
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) error {
+	// Events must be drained while polling, so the responses to our
+	// commands aren't stuck behind them.
+	go func() {
+		for {
+			select {
+			case ev := <-e.conn.EventQueue():
+				e.HandleEvent(ev)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		e.Collect()
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Collect polls wpa_supplicant once.  SignalPoll and PacketCountPoll are
+// expected to fail while disconnected, in which case their metrics are
+// omitted.
+func (e *Exporter) Collect() {
+	status, err := e.conn.Status()
+	var signal wpasupplicant.SignalPollResult
+	var packets wpasupplicant.PacketCountResult
+	var scan []wpasupplicant.ScanResult
+	if err == nil {
+		signal, _ = e.conn.SignalPoll()
+		packets, _ = e.conn.PacketCountPoll()
+		scan, _ = e.conn.ScanResults()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.up = err == nil
+	e.status, e.signal, e.packets, e.scan = status, signal, packets, scan
+}
+
+// HandleEvent counts an event.  Run() calls this for each event; it is
+// exported for callers who read the EventQueue themselves.
+func (e *Exporter) HandleEvent(ev wpasupplicant.WPAEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.count("wpa_supplicant_events_total", ev.Event)
+
+	switch ev.Event {
+	case "CONNECTED":
+		e.count("wpa_supplicant_connects_total")
+	case "DISCONNECTED":
+		e.count("wpa_supplicant_disconnects_total", ev.Arguments["reason"])
+	case "SSID-TEMP-DISABLED":
+		e.count("wpa_supplicant_auth_failures_total", strings.ToLower(ev.Arguments["reason"]))
+	case "EAP-FAILURE":
+		e.count("wpa_supplicant_auth_failures_total", "eap_failure")
+	case "ASSOC-REJECT":
+		e.count("wpa_supplicant_assoc_rejects_total", ev.Arguments["status_code"])
+	}
+}
+
+// count increments a counter.  The label values must match the label names
+// in counterLabels.
+func (e *Exporter) count(name string, labelValues ...string) {
+	if e.counters[name] == nil {
+		e.counters[name] = make(map[string]float64)
+	}
+	e.counters[name][strings.Join(labelValues, "\x00")]++
+}
+
+// counterLabels are the label names of each counter, other than interface.
+var counterLabels = map[string][]string{
+	"wpa_supplicant_events_total":        {"event"},
+	"wpa_supplicant_connects_total":      nil,
+	"wpa_supplicant_disconnects_total":   {"reason"},
+	"wpa_supplicant_auth_failures_total": {"reason"},
+	"wpa_supplicant_assoc_rejects_total": {"status_code"},
+}
+
+// counterHelp is the help text of each counter.
+var counterHelp = map[string]string{
+	"wpa_supplicant_events_total":        "Events received from wpa_supplicant.",
+	"wpa_supplicant_connects_total":      "Successful connections.",
+	"wpa_supplicant_disconnects_total":   "Disconnections, by IEEE 802.11 reason code.",
+	"wpa_supplicant_auth_failures_total": "Authentication failures, by reason.",
+	"wpa_supplicant_assoc_rejects_total": "Association rejections, by IEEE 802.11 status code.",
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	e.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (e *Exporter) WriteTo(w io.Writer) (int64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	b := &bytes.Buffer{}
+	iface := label{"interface", e.iface}
+
+	up := 0.0
+	if e.up {
+		up = 1
+	}
+	writeFamily(b, "wpa_supplicant_up", "gauge", "Whether wpa_supplicant responded to the last poll.",
+		sample{[]label{iface}, up})
+
+	if e.status != nil {
+		link := []label{iface, {"ssid", ssidLabel(e.status.SSID())}, {"bssid", e.status.BSSID().String()}}
+
+		writeFamily(b, "wpa_supplicant_info", "gauge", "Current state of wpa_supplicant.",
+			sample{append(link, label{"wpa_state", e.status.WPAState()}, label{"key_mgmt", e.status.KeyMgmt()}), 1})
+
+		connected := 0.0
+		if e.status.WPAState() == "COMPLETED" {
+			connected = 1
+		}
+		writeFamily(b, "wpa_supplicant_connected", "gauge", "Whether the interface is connected.",
+			sample{[]label{iface}, connected})
+
+		if e.status.Frequency() != 0 {
+			writeFamily(b, "wpa_supplicant_frequency_mhz", "gauge", "Frequency of the current BSS.",
+				sample{link, float64(e.status.Frequency())})
+		}
+
+		if e.signal != nil {
+			writeFamily(b, "wpa_supplicant_signal_rssi_dbm", "gauge", "Received signal strength of the current link.",
+				sample{link, float64(e.signal.RSSI())})
+			writeFamily(b, "wpa_supplicant_signal_average_rssi_dbm", "gauge", "Average received signal strength of the current link.",
+				sample{link, float64(e.signal.AverageRSSI())})
+			writeFamily(b, "wpa_supplicant_link_speed_mbps", "gauge", "Transmit rate of the current link.",
+				sample{link, float64(e.signal.LinkSpeed())})
+			if e.signal.Noise() != 9999 {
+				writeFamily(b, "wpa_supplicant_noise_dbm", "gauge", "Noise level of the current link.",
+					sample{link, float64(e.signal.Noise())})
+			}
+		}
+
+		if e.packets != nil {
+			writeFamily(b, "wpa_supplicant_tx_packets_total", "counter", "Packets transmitted on the current link.",
+				sample{link, float64(e.packets.TxPackets())})
+			writeFamily(b, "wpa_supplicant_tx_failures_total", "counter", "Packets which failed to transmit on the current link.",
+				sample{link, float64(e.packets.TxFailures())})
+			writeFamily(b, "wpa_supplicant_rx_packets_total", "counter", "Packets received on the current link.",
+				sample{link, float64(e.packets.RxPackets())})
+		}
+	}
+
+	if e.scan != nil {
+		writeFamily(b, "wpa_supplicant_scan_results", "gauge", "Number of BSSs in the latest scan results.",
+			sample{[]label{iface}, float64(len(e.scan))})
+
+		var samples []sample
+		for _, bss := range e.scan {
+			samples = append(samples, sample{[]label{
+				iface,
+				{"ssid", ssidLabel(bss.SSID())},
+				{"bssid", bss.BSSID().String()},
+				{"frequency", strconv.Itoa(bss.Frequency())},
+			}, float64(bss.RSSI())})
+		}
+		writeFamily(b, "wpa_supplicant_scan_rssi_dbm", "gauge", "Received signal strength of each scanned BSS.", samples...)
+	}
+
+	var names []string
+	for name := range counterLabels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var samples []sample
+		for values, n := range e.counters[name] {
+			labels := []label{iface}
+			if len(counterLabels[name]) > 0 {
+				for i, v := range strings.Split(values, "\x00") {
+					labels = append(labels, label{counterLabels[name][i], v})
+				}
+			}
+			samples = append(samples, sample{labels, n})
+		}
+		if len(samples) == 0 && len(counterLabels[name]) == 0 {
+			samples = append(samples, sample{[]label{iface}, 0})
+		}
+		writeFamily(b, name, "counter", counterHelp[name], samples...)
+	}
+
+	return b.WriteTo(w)
+}
+
+type label struct {
+	name, value string
+}
+
+type sample struct {
+	labels []label
+	value  float64
+}
+
+// writeFamily writes a metric family, with its samples in a stable order.
+func writeFamily(w io.Writer, name, typ, help string, samples ...sample) {
+	lines := make([]string, len(samples))
+	for i, s := range samples {
+		var labels []string
+		for _, l := range s.labels {
+			labels = append(labels, fmt.Sprintf("%s=\"%s\"", l.name, escapeLabel(l.value)))
+		}
+		lines[i] = fmt.Sprintf("%s{%s} %s\n", name, strings.Join(labels, ","), strconv.FormatFloat(s.value, 'g', -1, 64))
+	}
+	sort.Strings(lines)
+
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+	for _, ln := range lines {
+		io.WriteString(w, ln)
+	}
+}
+
+// escapeLabel escapes a label value for the text exposition format.
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// ssidLabel returns a printable form of a raw SSID.
+func ssidLabel(ssid string) string {
+	return wpasupplicant.SSID(ssid).String()
+}
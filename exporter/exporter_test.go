@@ -0,0 +1,161 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package exporter
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"pifke.org/wpasupplicant"
+)
+
+// fakeConn implements the parts of wpasupplicant.Conn used by Exporter.
+type fakeConn struct {
+	wpasupplicant.Conn
+	connected bool
+}
+
+type fakeStatus struct {
+	wpasupplicant.StatusResult
+}
+
+func (fakeStatus) WPAState() string        { return "COMPLETED" }
+func (fakeStatus) KeyMgmt() string         { return "WPA2-PSK" }
+func (fakeStatus) SSID() string            { return "home \"wifi\"" }
+func (fakeStatus) BSSID() net.HardwareAddr { return net.HardwareAddr{2, 0, 0, 0, 1, 0} }
+func (fakeStatus) Frequency() int          { return 2412 }
+
+type fakeSignal struct {
+	wpasupplicant.SignalPollResult
+}
+
+func (fakeSignal) RSSI() int        { return -60 }
+func (fakeSignal) AverageRSSI() int { return -61 }
+func (fakeSignal) LinkSpeed() int   { return 72 }
+func (fakeSignal) Noise() int       { return 9999 }
+
+type fakePackets struct {
+	wpasupplicant.PacketCountResult
+}
+
+func (fakePackets) TxPackets() uint64  { return 100 }
+func (fakePackets) TxFailures() uint64 { return 2 }
+func (fakePackets) RxPackets() uint64  { return 300 }
+
+type fakeBSS struct {
+	wpasupplicant.ScanResult
+}
+
+func (fakeBSS) SSID() string            { return "\x00" }
+func (fakeBSS) BSSID() net.HardwareAddr { return net.HardwareAddr{2, 0, 0, 0, 2, 0} }
+func (fakeBSS) Frequency() int          { return 5180 }
+func (fakeBSS) RSSI() int               { return -80 }
+
+func (c *fakeConn) Status() (wpasupplicant.StatusResult, error) {
+	if !c.connected {
+		return nil, errors.New("connection refused")
+	}
+	return fakeStatus{}, nil
+}
+
+func (c *fakeConn) SignalPoll() (wpasupplicant.SignalPollResult, error) {
+	return fakeSignal{}, nil
+}
+
+func (c *fakeConn) PacketCountPoll() (wpasupplicant.PacketCountResult, error) {
+	return fakePackets{}, nil
+}
+
+func (c *fakeConn) ScanResults() ([]wpasupplicant.ScanResult, []error) {
+	return []wpasupplicant.ScanResult{fakeBSS{}}, nil
+}
+
+func TestExporter(t *testing.T) {
+	e := New(&fakeConn{connected: true}, "wlan0")
+	e.Collect()
+
+	e.HandleEvent(wpasupplicant.WPAEvent{Event: "CONNECTED"})
+	e.HandleEvent(wpasupplicant.WPAEvent{Event: "DISCONNECTED", Arguments: map[string]string{"reason": "3"}})
+	e.HandleEvent(wpasupplicant.WPAEvent{Event: "DISCONNECTED", Arguments: map[string]string{"reason": "3"}})
+	e.HandleEvent(wpasupplicant.WPAEvent{Event: "SSID-TEMP-DISABLED", Arguments: map[string]string{"reason": "WRONG_KEY"}})
+
+	b := &bytes.Buffer{}
+	e.WriteTo(b)
+	out := b.String()
+
+	link := `interface="wlan0",ssid="home \"wifi\"",bssid="02:00:00:00:01:00"`
+	for _, expect := range []string{
+		`wpa_supplicant_up{interface="wlan0"} 1`,
+		`wpa_supplicant_info{` + link + `,wpa_state="COMPLETED",key_mgmt="WPA2-PSK"} 1`,
+		`wpa_supplicant_connected{interface="wlan0"} 1`,
+		`wpa_supplicant_frequency_mhz{` + link + `} 2412`,
+		`wpa_supplicant_signal_rssi_dbm{` + link + `} -60`,
+		`wpa_supplicant_link_speed_mbps{` + link + `} 72`,
+		`wpa_supplicant_tx_failures_total{` + link + `} 2`,
+		`wpa_supplicant_rx_packets_total{` + link + `} 300`,
+		`wpa_supplicant_scan_results{interface="wlan0"} 1`,
+		`wpa_supplicant_scan_rssi_dbm{interface="wlan0",ssid="\\x00",bssid="02:00:00:00:02:00",frequency="5180"} -80`,
+		`wpa_supplicant_connects_total{interface="wlan0"} 1`,
+		`wpa_supplicant_disconnects_total{interface="wlan0",reason="3"} 2`,
+		`wpa_supplicant_auth_failures_total{interface="wlan0",reason="wrong_key"} 1`,
+		`wpa_supplicant_events_total{interface="wlan0",event="DISCONNECTED"} 2`,
+		"# TYPE wpa_supplicant_tx_packets_total counter",
+	} {
+		if !strings.Contains(out, expect+"\n") {
+			t.Errorf("missing %s", expect)
+		}
+	}
+
+	// Unknown noise shouldn't be reported.
+	if strings.Contains(out, "wpa_supplicant_noise_dbm{") {
+		t.Error("unexpected noise metric")
+	}
+}
+
+func TestExporterDown(t *testing.T) {
+	e := New(&fakeConn{}, "wlan0")
+	e.Collect()
+
+	b := &bytes.Buffer{}
+	e.WriteTo(b)
+	out := b.String()
+
+	if !strings.Contains(out, `wpa_supplicant_up{interface="wlan0"} 0`+"\n") {
+		t.Errorf("expected wpa_supplicant_up 0, got:\n%s", out)
+	}
+	if strings.Contains(out, "wpa_supplicant_info{") {
+		t.Error("unexpected info metric while down")
+	}
+	if !strings.Contains(out, `wpa_supplicant_connects_total{interface="wlan0"} 0`+"\n") {
+		t.Error("missing zero connects counter")
+	}
+}
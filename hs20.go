@@ -0,0 +1,417 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Credential is a Hotspot 2.0 (Passpoint) credential, used to select and
+// connect to networks operated by the home service provider or its roaming
+// partners.  Fields are tagged with their cred block variable names, the
+// same way as NetworkConfig.
+type Credential struct {
+	// Priority and SPPriority rank credentials, and service providers
+	// matching the same credential.  Higher Priority is preferred, as is
+	// lower SPPriority.
+	Priority   int `wpa:"priority"`
+	SPPriority int `wpa:"sp_priority"`
+
+	// Realm is the home NAI realm, e.g. "example.com".
+	Realm string `wpa:"realm"`
+
+	// Domain is the home service provider's FQDN, used to tell home
+	// networks from roaming ones.
+	Domain string `wpa:"domain"`
+
+	// DomainSuffixMatch constrains the server certificate.
+	DomainSuffixMatch string `wpa:"domain_suffix_match"`
+
+	// Username and Password are used with EAP-TTLS and similar
+	// username/password methods.  Unlike EAPConfig.Password, cred blocks
+	// do not support password hashes, so a "hash:" prefix is part of the
+	// password.
+	Username string `wpa:"username"`
+	Password string `wpa:"password,secret"`
+
+	// CACert, ClientCert, PrivateKey and PrivateKeyPasswd are the
+	// certificate and private key files, as in EAPConfig.
+	CACert           string `wpa:"ca_cert"`
+	ClientCert       string `wpa:"client_cert"`
+	PrivateKey       string `wpa:"private_key"`
+	PrivateKeyPasswd string `wpa:"private_key_passwd,secret"`
+
+	// EAP is the EAP method to use, which overrides the method advertised
+	// by the access point.
+	EAP EAPMethod `wpa:"eap,raw"`
+
+	// Phase1 and Phase2 are parameters for the outer and inner
+	// authentication, e.g. "auth=MSCHAPV2".
+	Phase1 string `wpa:"phase1"`
+	Phase2 string `wpa:"phase2"`
+
+	// RoamingConsortium is the hex-encoded Organization Identifier of
+	// the roaming consortium the credential belongs to.
+	// RequiredRoamingConsortium additionally requires the access point
+	// to advertise that OI.
+	RoamingConsortium         string `wpa:"roaming_consortium,raw"`
+	RequiredRoamingConsortium string `wpa:"required_roaming_consortium,raw"`
+
+	// IMSI is the SIM's IMSI, formatted as "<MCC><MNC>-<MSIN>", for
+	// EAP-SIM and EAP-AKA credentials.  Milenage is the
+	// "<Ki>:<OPc>:<SQN>" used to simulate a SIM in software, and SIMNum
+	// selects the SIM card to use if there are several.
+	IMSI     string `wpa:"imsi"`
+	Milenage string `wpa:"milenage,secret"`
+	SIMNum   int    `wpa:"sim_num"`
+
+	// ProvisioningSP is the FQDN of the service provider which
+	// provisioned the credential, and UpdateIdentifier is its
+	// subscription update counter.
+	ProvisioningSP   string `wpa:"provisioning_sp"`
+	UpdateIdentifier int    `wpa:"update_identifier"`
+
+	// OCSP configures certificate status checking, as in EAPConfig.
+	OCSP int `wpa:"ocsp"`
+}
+
+// credentialFields lists the cred block variables in Credential, in the
+// order they are set.
+var credentialFields = parseNetworkFields(reflect.TypeOf(Credential{}))
+
+// credentialFieldsByName indexes credentialFields by variable name.
+var credentialFieldsByName = func() map[string]networkField {
+	m := make(map[string]networkField, len(credentialFields))
+	for _, f := range credentialFields {
+		m[f.name] = f
+	}
+	return m
+}()
+
+// Redacted returns a copy of cred with its secrets, such as the password
+// and Milenage parameters, cleared.
+func (cred Credential) Redacted() Credential {
+	v := reflect.ValueOf(&cred).Elem()
+	for _, f := range credentialFields {
+		if f.secret {
+			fv := v.FieldByIndex(f.index)
+			fv.Set(reflect.Zero(fv.Type()))
+		}
+	}
+	return cred
+}
+
+// ConfiguredCredential is a credential returned by
+// Passpoint.ListCredentials().
+type ConfiguredCredential interface {
+	// CredentialID is the ID used to refer to the credential in other
+	// commands, such as Passpoint.RemoveCredential().
+	CredentialID() int
+
+	Realm() string
+	Username() string
+
+	// Domain is the first home service provider FQDN.
+	Domain() string
+
+	IMSI() string
+}
+
+type configuredCredential struct {
+	credentialID                  int
+	realm, username, domain, imsi string
+}
+
+func (r *configuredCredential) CredentialID() int { return r.credentialID }
+func (r *configuredCredential) Realm() string     { return r.realm }
+func (r *configuredCredential) Username() string  { return r.username }
+func (r *configuredCredential) Domain() string    { return r.domain }
+func (r *configuredCredential) IMSI() string      { return r.imsi }
+
+// Passpoint manages Hotspot 2.0 credentials and interworking network
+// selection using a Conn.  Selection results are reported as
+// INTERWORKING-AP and INTERWORKING-NO-MATCH events on the Conn's
+// EventQueue.
+type Passpoint struct {
+	c Conn
+}
+
+// NewPasspoint returns a Passpoint which sends commands using the specified
+// Conn.
+func NewPasspoint(c Conn) *Passpoint {
+	return &Passpoint{c: c}
+}
+
+// AddCredential adds a credential, returning its ID.  If setting any of its
+// variables fails, the credential is removed again.
+func (p *Passpoint) AddCredential(cred Credential) (int, error) {
+	resp, err := p.c.Command("ADD_CRED")
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := strconv.Atoi(strings.TrimSuffix(resp, "\n"))
+	if err != nil {
+		return -1, &ParseError{Line: resp, Err: err}
+	}
+
+	for _, f := range credentialFields {
+		value, ok := f.get(&cred)
+		if !ok {
+			continue
+		}
+
+		if err = p.SetCredential(id, f.name, value); err != nil {
+			p.RemoveCredential(id)
+			return -1, err
+		}
+	}
+
+	return id, nil
+}
+
+// SetCredential sets a variable of the credential identified by
+// credentialID.  The value is encoded the same way as Credential's field
+// for the variable, so strings should not be quoted.
+func (p *Passpoint) SetCredential(credentialID int, variable, value string) error {
+	f, ok := credentialFieldsByName[variable]
+	if !ok {
+		f.kind = kindString
+	}
+	return runOK(p.c, fmt.Sprintf("SET_CRED %d %s %s", credentialID, variable, f.encode(value)))
+}
+
+// GetCredential returns a variable of the credential identified by
+// credentialID, encoded as in wpa_supplicant.conf.
+func (p *Passpoint) GetCredential(credentialID int, variable string) (string, error) {
+	resp, err := p.c.Command(fmt.Sprintf("GET_CRED %d %s", credentialID, variable))
+	if err != nil {
+		return "", err
+	}
+
+	resp = strings.TrimSuffix(resp, "\n")
+	if resp == "FAIL" {
+		return "", &ParseError{Line: resp}
+	}
+	return resp, nil
+}
+
+// ReadCredential reads the credential identified by credentialID.  Secrets
+// (such as the password) cannot be read back, and are left empty.
+func (p *Passpoint) ReadCredential(credentialID int) (Credential, error) {
+	var cred Credential
+	for _, f := range credentialFields {
+		resp, err := p.c.Command(fmt.Sprintf("GET_CRED %d %s", credentialID, f.name))
+		if err != nil {
+			return cred, err
+		}
+
+		// Unset variables return FAIL.  Secrets return "*".
+		resp = strings.TrimSuffix(resp, "\n")
+		if resp == "FAIL" || resp == "" || (f.secret && resp == "*") {
+			continue
+		}
+
+		if err = f.set(&cred, resp); err != nil {
+			return cred, &ParseError{Line: resp, Err: err}
+		}
+	}
+
+	return cred, nil
+}
+
+// ListCredentials returns the configured credentials.
+func (p *Passpoint) ListCredentials() ([]ConfiguredCredential, error) {
+	resp, err := p.c.Command("LIST_CREDS")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseListCredsResult(resp)
+}
+
+// parseListCredsResult parses a LIST_CREDS response, which is a table like:
+//
+//	cred id / realm / username / domain / imsi
+//	0	example.com	user	example.com
+func parseListCredsResult(resp string) ([]ConfiguredCredential, error) {
+	s := bufio.NewScanner(strings.NewReader(resp))
+	if !s.Scan() {
+		return nil, &ParseError{Line: resp}
+	}
+
+	cols := strings.Split(s.Text(), " / ")
+	if cols[0] != "cred id" {
+		return nil, &ParseError{Line: s.Text()}
+	}
+
+	var res []ConfiguredCredential
+	for s.Scan() {
+		ln := s.Text()
+		fields := strings.Split(ln, "\t")
+
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, &ParseError{Line: ln, Err: err}
+		}
+
+		cred := &configuredCredential{credentialID: id}
+		for i, col := range cols {
+			if i >= len(fields) {
+				break
+			}
+
+			switch col {
+			case "realm":
+				cred.realm = fields[i]
+			case "username":
+				cred.username = fields[i]
+			case "domain":
+				cred.domain = fields[i]
+			case "imsi":
+				cred.imsi = fields[i]
+			}
+		}
+		res = append(res, cred)
+	}
+
+	return res, nil
+}
+
+// RemoveCredential removes the credential identified by credentialID.
+func (p *Passpoint) RemoveCredential(credentialID int) error {
+	return runOK(p.c, fmt.Sprintf("REMOVE_CRED %d", credentialID))
+}
+
+// RemoveAllCredentials removes all configured credentials.
+func (p *Passpoint) RemoveAllCredentials() error {
+	return runOK(p.c, "REMOVE_CRED all")
+}
+
+// Select fetches ANQP information from nearby access points and matches it
+// against the configured credentials, sending an INTERWORKING-AP event for
+// each match or INTERWORKING-NO-MATCH if there are none.  If auto is true,
+// wpa_supplicant also connects to the best match.
+func (p *Passpoint) Select(auto bool) error {
+	if auto {
+		return runOK(p.c, "INTERWORKING_SELECT auto")
+	}
+	return runOK(p.c, "INTERWORKING_SELECT")
+}
+
+// Connect connects to the access point identified by bssid, which should
+// have been reported by an INTERWORKING-AP event, using the matching
+// credential.
+func (p *Passpoint) Connect(bssid net.HardwareAddr) error {
+	return runOK(p.c, "INTERWORKING_CONNECT "+bssid.String())
+}
+
+// InterworkingType is whether an access point is operated by the home
+// service provider.
+type InterworkingType string
+
+const (
+	InterworkingHome    InterworkingType = "home"
+	InterworkingRoaming InterworkingType = "roaming"
+	InterworkingUnknown InterworkingType = "unknown"
+)
+
+// InterworkingAP is an INTERWORKING-AP event, sent by Passpoint.Select() for
+// each access point matching a credential.  It looks like:
+//
+//	INTERWORKING-AP 02:00:00:00:01:00 type=home id=0 priority=0 sp_priority=0
+type InterworkingAP struct {
+	// BSSID is the access point to pass to Passpoint.Connect().
+	BSSID net.HardwareAddr
+
+	// Type is whether the access point is operated by the home service
+	// provider or a roaming partner.
+	Type InterworkingType
+
+	// CredentialID is the ID of the matching credential, or -1 if not
+	// reported.
+	CredentialID int
+
+	// Priority and SPPriority are those of the matching credential.
+	Priority   int
+	SPPriority int
+
+	// BelowMinBackhaul, OverMaxBSSLoad and ConnCapabMissing are set if
+	// the access point doesn't meet the minimum backhaul, BSS load or
+	// connection capability requirements.  Such access points are only
+	// selected if no others match.
+	BelowMinBackhaul bool
+	OverMaxBSSLoad   bool
+	ConnCapabMissing bool
+}
+
+// ParseInterworkingAP returns the INTERWORKING-AP event described by ev.
+// The second return value is false if ev isn't an INTERWORKING-AP event.
+func ParseInterworkingAP(ev WPAEvent) (*InterworkingAP, bool) {
+	if ev.Event != "INTERWORKING-AP" {
+		return nil, false
+	}
+
+	args, other := eventArgs(ev.Line)
+	if len(other) == 0 {
+		return nil, false
+	}
+	bssid, err := net.ParseMAC(other[0])
+	if err != nil {
+		return nil, false
+	}
+
+	res := &InterworkingAP{
+		BSSID:            bssid,
+		Type:             InterworkingType(args["type"]),
+		CredentialID:     -1,
+		BelowMinBackhaul: args["below_min_backhaul"] == "1",
+		OverMaxBSSLoad:   args["over_max_bss_load"] == "1",
+		ConnCapabMissing: args["conn_capab_missing"] == "1",
+	}
+	if id, err := strconv.Atoi(args["id"]); err == nil {
+		res.CredentialID = id
+	}
+	res.Priority, _ = strconv.Atoi(args["priority"])
+	res.SPPriority, _ = strconv.Atoi(args["sp_priority"])
+
+	return res, true
+}
+
+// IsInterworkingNoMatch returns true if ev is an INTERWORKING-NO-MATCH event,
+// sent by Passpoint.Select() when no access point matches the configured
+// credentials.
+func IsInterworkingNoMatch(ev WPAEvent) bool {
+	return ev.Event == "INTERWORKING-NO-MATCH"
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"net"
+	"testing"
+)
+
+func TestPasspointCredentials(t *testing.T) {
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch cmd {
+		case "ADD_CRED":
+			return "0\n"
+		case "LIST_CREDS":
+			return "cred id / realm / username / domain / imsi\n" +
+				"0\texample.com\tuser\texample.com\t\n" +
+				"1\t\t\t\t310026-000000000\n"
+		case "GET_CRED 0 realm", "GET_CRED 0 domain":
+			return `"example.com"` + "\n"
+		case "GET_CRED 0 username":
+			return `"user"` + "\n"
+		case "GET_CRED 0 password":
+			return "*\n"
+		case "GET_CRED 0 eap":
+			return "TTLS\n"
+		case "GET_CRED 0 priority":
+			return "1\n"
+		case "GET_CRED 0 roaming_consortium":
+			return "223344\n"
+		}
+		if len(cmd) > 8 && cmd[:8] == "GET_CRED" {
+			return "FAIL\n"
+		}
+		return ""
+	})
+	p := NewPasspoint(conn)
+
+	cred := Credential{
+		Priority:          1,
+		Realm:             "example.com",
+		Domain:            "example.com",
+		Username:          "user",
+		Password:          "secret",
+		EAP:               EAP_TTLS,
+		Phase2:            "auth=MSCHAPV2",
+		RoamingConsortium: "223344",
+	}
+	id, err := p.AddCredential(cred)
+	if err != nil || id != 0 {
+		t.Fatalf("AddCredential: got %d, %v", id, err)
+	}
+
+	creds, err := p.ListCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(creds) != 2 || creds[0].CredentialID() != 0 || creds[0].Realm() != "example.com" ||
+		creds[0].Username() != "user" || creds[0].Domain() != "example.com" ||
+		creds[1].CredentialID() != 1 || creds[1].IMSI() != "310026-000000000" {
+		t.Errorf("ListCredentials: got %+v", creds)
+	}
+
+	read, err := p.ReadCredential(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred.Phase2 = ""; read != cred.Redacted() {
+		t.Errorf("ReadCredential: got %+v", read)
+	}
+
+	if err = p.RemoveCredential(0); err != nil {
+		t.Error(err)
+	}
+	if err = p.RemoveAllCredentials(); err != nil {
+		t.Error(err)
+	}
+	if err = p.Select(false); err != nil {
+		t.Error(err)
+	}
+	if err = p.Select(true); err != nil {
+		t.Error(err)
+	}
+	if err = p.Connect(net.HardwareAddr{2, 0, 0, 0, 1, 0}); err != nil {
+		t.Error(err)
+	}
+
+	fs.expectCommands(
+		"ADD_CRED",
+		"SET_CRED 0 priority 1",
+		`SET_CRED 0 realm "example.com"`,
+		`SET_CRED 0 domain "example.com"`,
+		`SET_CRED 0 username "user"`,
+		`SET_CRED 0 password "secret"`,
+		"SET_CRED 0 eap TTLS",
+		`SET_CRED 0 phase2 "auth=MSCHAPV2"`,
+		"SET_CRED 0 roaming_consortium 223344",
+		"LIST_CREDS",
+		"GET_CRED 0 priority",
+		"GET_CRED 0 sp_priority",
+		"GET_CRED 0 realm",
+		"GET_CRED 0 domain",
+		"GET_CRED 0 domain_suffix_match",
+		"GET_CRED 0 username",
+		"GET_CRED 0 password",
+		"GET_CRED 0 ca_cert",
+		"GET_CRED 0 client_cert",
+		"GET_CRED 0 private_key",
+		"GET_CRED 0 private_key_passwd",
+		"GET_CRED 0 eap",
+		"GET_CRED 0 phase1",
+		"GET_CRED 0 phase2",
+		"GET_CRED 0 roaming_consortium",
+		"GET_CRED 0 required_roaming_consortium",
+		"GET_CRED 0 imsi",
+		"GET_CRED 0 milenage",
+		"GET_CRED 0 sim_num",
+		"GET_CRED 0 provisioning_sp",
+		"GET_CRED 0 update_identifier",
+		"GET_CRED 0 ocsp",
+		"REMOVE_CRED 0",
+		"REMOVE_CRED all",
+		"INTERWORKING_SELECT",
+		"INTERWORKING_SELECT auto",
+		"INTERWORKING_CONNECT 02:00:00:00:01:00",
+	)
+}
+
+func TestAddCredentialFailure(t *testing.T) {
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch cmd {
+		case "ADD_CRED":
+			return "2\n"
+		case `SET_CRED 2 realm "example.com"`:
+			return "FAIL\n"
+		}
+		return ""
+	})
+
+	if _, err := NewPasspoint(conn).AddCredential(Credential{Realm: "example.com"}); err == nil {
+		t.Error("expected error")
+	}
+
+	fs.expectCommands(
+		"ADD_CRED",
+		`SET_CRED 2 realm "example.com"`,
+		"REMOVE_CRED 2",
+	)
+}
+
+func TestSetCredentialPassword(t *testing.T) {
+	fs, conn := newFakeServer(t, func(cmd string) string { return "" })
+
+	if err := NewPasspoint(conn).SetCredential(0, "password", "hash:0123"); err != nil {
+		t.Error(err)
+	}
+
+	fs.expectCommands(`SET_CRED 0 password "hash:0123"`)
+}
+
+func TestParseInterworkingEvents(t *testing.T) {
+	ap, ok := ParseInterworkingAP(WPAEvent{
+		Event: "INTERWORKING-AP",
+		Line:  "INTERWORKING-AP 02:00:00:00:01:00 type=roaming over_max_bss_load=1 id=1 priority=2 sp_priority=3",
+	})
+	if !ok || ap.BSSID.String() != "02:00:00:00:01:00" || ap.Type != InterworkingRoaming ||
+		ap.CredentialID != 1 || ap.Priority != 2 || ap.SPPriority != 3 ||
+		!ap.OverMaxBSSLoad || ap.BelowMinBackhaul || ap.ConnCapabMissing {
+		t.Errorf("INTERWORKING-AP: got %+v", ap)
+	}
+
+	if _, ok = ParseInterworkingAP(WPAEvent{Event: "INTERWORKING-NO-MATCH"}); ok {
+		t.Error("parsed INTERWORKING-NO-MATCH as INTERWORKING-AP")
+	}
+
+	if !IsInterworkingNoMatch(WPAEvent{
+		Event: "INTERWORKING-NO-MATCH",
+		Line:  "INTERWORKING-NO-MATCH No network with matching credentials found",
+	}) {
+		t.Error("INTERWORKING-NO-MATCH not recognized")
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// MeshNetworkConfig returns the configuration of a mesh (802.11s) network
+// on the specified frequency, in Mhz.  If password is empty, the mesh is
+// open; otherwise it is secured using SAE.  Pass the network's ID to
+// Mesh.GroupAdd() to join it.
+func MeshNetworkConfig(ssid, password string, freq int) NetworkConfig {
+	cfg := NetworkConfig{
+		SSID:      ssid,
+		Mode:      ModeMesh,
+		Frequency: freq,
+		KeyMgmt:   "NONE",
+	}
+	if password != "" {
+		cfg.KeyMgmt = "SAE"
+		cfg.SAEPassword = password
+		cfg.IEEE80211w = 1
+	}
+	return cfg
+}
+
+// IsMesh returns true if the scanned BSS is a mesh (802.11s) network.
+func IsMesh(r ScanResult) bool {
+	for _, f := range r.Flags() {
+		if f == "MESH" {
+			return true
+		}
+	}
+	return false
+}
+
+// Mesh controls mesh (802.11s) networking using a Conn.  Mesh events, such
+// as MESH-PEER-CONNECTED, are sent on the EventQueue of the Conn for the
+// mesh interface.
+type Mesh struct {
+	c Conn
+}
+
+// NewMesh returns a Mesh which sends commands using the specified Conn.
+func NewMesh(c Conn) *Mesh {
+	return &Mesh{c: c}
+}
+
+// InterfaceAdd creates a separate interface for the mesh, returning its
+// name.  If ifName is empty, wpa_supplicant picks a name such as
+// mesh-wlan0-0.  Use UnixgramDir() to connect to the new interface.
+func (m *Mesh) InterfaceAdd(ifName string) (string, error) {
+	cmd := "MESH_INTERFACE_ADD"
+	if ifName != "" {
+		cmd += " ifname=" + ifName
+	}
+
+	resp, err := m.c.Command(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	resp = strings.TrimSuffix(resp, "\n")
+	if resp == "" || resp == "FAIL" {
+		return "", &ParseError{Line: resp}
+	}
+	return resp, nil
+}
+
+// GroupAdd starts or joins the mesh configured by the network identified by
+// networkID, which must have Mode set to ModeMesh.  MESH-GROUP-STARTED is
+// sent once the mesh is up.
+func (m *Mesh) GroupAdd(networkID int) error {
+	return runOK(m.c, fmt.Sprintf("MESH_GROUP_ADD %d", networkID))
+}
+
+// GroupRemove leaves the mesh on the specified interface.
+func (m *Mesh) GroupRemove(ifName string) error {
+	return runOK(m.c, "MESH_GROUP_REMOVE "+ifName)
+}
+
+// PeerAdd establishes a peering with the mesh peer identified by addr.
+// duration is how long, in seconds, to wait for the peer to be found, or 0
+// for wpa_supplicant's default.
+func (m *Mesh) PeerAdd(addr net.HardwareAddr, duration int) error {
+	cmd := "MESH_PEER_ADD " + addr.String()
+	if duration != 0 {
+		cmd += fmt.Sprintf(" duration=%d", duration)
+	}
+	return runOK(m.c, cmd)
+}
+
+// PeerRemove closes the peering with the mesh peer identified by addr.
+func (m *Mesh) PeerRemove(addr net.HardwareAddr) error {
+	return runOK(m.c, "MESH_PEER_REMOVE "+addr.String())
+}
+
+// MeshGroupStarted is a MESH-GROUP-STARTED event, sent when this device
+// joins a mesh.  It looks like:
+//
+//	MESH-GROUP-STARTED ssid="mesh" id=0
+type MeshGroupStarted struct {
+	// SSID is the raw mesh ID.
+	SSID string
+
+	// NetworkID is the ID of the network configuring the mesh.
+	NetworkID int
+}
+
+// ParseMeshGroupStarted returns the MESH-GROUP-STARTED event described by
+// ev.  The second return value is false if ev isn't a MESH-GROUP-STARTED
+// event.
+func ParseMeshGroupStarted(ev WPAEvent) (*MeshGroupStarted, bool) {
+	if ev.Event != "MESH-GROUP-STARTED" {
+		return nil, false
+	}
+
+	args, _ := eventArgs(ev.Line)
+	ssid, err := printfDecode(args["ssid"])
+	if err != nil {
+		return nil, false
+	}
+	id, err := strconv.Atoi(args["id"])
+	if err != nil {
+		return nil, false
+	}
+
+	return &MeshGroupStarted{SSID: ssid, NetworkID: id}, true
+}
+
+// MeshPeer is a MESH-PEER-CONNECTED or MESH-PEER-DISCONNECTED event, which
+// looks like:
+//
+//	MESH-PEER-CONNECTED 02:00:00:00:01:00
+type MeshPeer struct {
+	// Address is the MAC address of the peer.
+	Address net.HardwareAddr
+
+	// Connected is true for MESH-PEER-CONNECTED, and false for
+	// MESH-PEER-DISCONNECTED.
+	Connected bool
+}
+
+// ParseMeshPeer returns the MESH-PEER-CONNECTED or MESH-PEER-DISCONNECTED
+// event described by ev.  The second return value is false if ev isn't
+// one of those events.
+func ParseMeshPeer(ev WPAEvent) (*MeshPeer, bool) {
+	if ev.Event != "MESH-PEER-CONNECTED" && ev.Event != "MESH-PEER-DISCONNECTED" {
+		return nil, false
+	}
+
+	_, other := eventArgs(ev.Line)
+	if len(other) == 0 {
+		return nil, false
+	}
+	addr, err := net.ParseMAC(other[0])
+	if err != nil {
+		return nil, false
+	}
+
+	return &MeshPeer{
+		Address:   addr,
+		Connected: ev.Event == "MESH-PEER-CONNECTED",
+	}, true
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestMeshCommands(t *testing.T) {
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch cmd {
+		case "MESH_INTERFACE_ADD":
+			return "mesh-wlan0-0\n"
+		case "MESH_INTERFACE_ADD ifname=mesh0":
+			return "FAIL\n"
+		}
+		return ""
+	})
+	m := NewMesh(conn)
+	peer := net.HardwareAddr{2, 0, 0, 0, 1, 0}
+
+	if ifName, err := m.InterfaceAdd(""); err != nil || ifName != "mesh-wlan0-0" {
+		t.Errorf("InterfaceAdd: got %q, %v", ifName, err)
+	}
+	if _, err := m.InterfaceAdd("mesh0"); err == nil {
+		t.Error("InterfaceAdd: expected error")
+	}
+	if err := m.GroupAdd(1); err != nil {
+		t.Error(err)
+	}
+	if err := m.PeerAdd(peer, 0); err != nil {
+		t.Error(err)
+	}
+	if err := m.PeerAdd(peer, 30); err != nil {
+		t.Error(err)
+	}
+	if err := m.PeerRemove(peer); err != nil {
+		t.Error(err)
+	}
+	if err := m.GroupRemove("mesh-wlan0-0"); err != nil {
+		t.Error(err)
+	}
+
+	fs.expectCommands(
+		"MESH_INTERFACE_ADD",
+		"MESH_INTERFACE_ADD ifname=mesh0",
+		"MESH_GROUP_ADD 1",
+		"MESH_PEER_ADD 02:00:00:00:01:00",
+		"MESH_PEER_ADD 02:00:00:00:01:00 duration=30",
+		"MESH_PEER_REMOVE 02:00:00:00:01:00",
+		"MESH_GROUP_REMOVE mesh-wlan0-0",
+	)
+}
+
+func TestMeshNetworkConfig(t *testing.T) {
+	cfg := MeshNetworkConfig("mesh", "secret", 2437)
+	expect := NetworkConfig{SSID: "mesh", Mode: ModeMesh, Frequency: 2437, KeyMgmt: "SAE", SAEPassword: "secret", IEEE80211w: 1}
+	if cfg != expect {
+		t.Errorf("got %+v", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if cfg = MeshNetworkConfig("mesh", "", 2437); cfg.KeyMgmt != "NONE" || cfg.SAEPassword != "" {
+		t.Errorf("got %+v", cfg)
+	}
+
+	for _, cfg := range []NetworkConfig{
+		{SSID: "mesh", Mode: ModeMesh, KeyMgmt: "SAE"},
+		{SSID: "mesh", Mode: ModeMesh, Frequency: 2437, KeyMgmt: "WPA-PSK", PSK: "password"},
+	} {
+		if _, ok := cfg.Validate().(*ConfigError); !ok {
+			t.Errorf("%+v: expected ConfigError", cfg)
+		}
+	}
+}
+
+func TestParseMeshEvents(t *testing.T) {
+	started, ok := ParseMeshGroupStarted(WPAEvent{
+		Event: "MESH-GROUP-STARTED",
+		Line:  `MESH-GROUP-STARTED ssid="my mesh" id=2`,
+	})
+	if !ok || started.SSID != "my mesh" || started.NetworkID != 2 {
+		t.Errorf("MESH-GROUP-STARTED: got %+v", started)
+	}
+
+	peer, ok := ParseMeshPeer(WPAEvent{
+		Event: "MESH-PEER-CONNECTED",
+		Line:  "MESH-PEER-CONNECTED 02:00:00:00:01:00",
+	})
+	if !ok || peer.Address.String() != "02:00:00:00:01:00" || !peer.Connected {
+		t.Errorf("MESH-PEER-CONNECTED: got %+v", peer)
+	}
+
+	peer, ok = ParseMeshPeer(WPAEvent{
+		Event: "MESH-PEER-DISCONNECTED",
+		Line:  "MESH-PEER-DISCONNECTED 02:00:00:00:01:00",
+	})
+	if !ok || peer.Connected {
+		t.Errorf("MESH-PEER-DISCONNECTED: got %+v", peer)
+	}
+
+	if _, ok = ParseMeshPeer(WPAEvent{Event: "MESH-GROUP-REMOVED", Line: "MESH-GROUP-REMOVED mesh-wlan0-0"}); ok {
+		t.Error("parsed MESH-GROUP-REMOVED as a peer event")
+	}
+}
+
+func TestScanResultMesh(t *testing.T) {
+	input := "bssid / frequency / signal level / flags / ssid\n" +
+		"02:00:00:00:01:00\t2437\t-50\t[MESH]\tmesh\n" +
+		"02:00:00:00:02:00\t2437\t-50\t[WPA2-PSK-CCMP][ESS]\tap\n"
+
+	res, errs := parseScanResults(bytes.NewBufferString(input))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if !IsMesh(res[0]) || IsMesh(res[1]) {
+		t.Errorf("wrong mesh flags (got %v, %v)", IsMesh(res[0]), IsMesh(res[1]))
+	}
+}
@@ -0,0 +1,530 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NetworkConfig is the configuration of a network block.  Each field
+// corresponds to a network variable, named in the field's wpa tag.  Fields
+// with their zero value are left at wpa_supplicant's default.
+//
+// See wpa_supplicant.conf(5) for the meaning of each variable.
+type NetworkConfig struct {
+	// SSID is the raw SSID of the network.  It is sent in hex if it
+	// isn't printable ASCII.
+	SSID string `wpa:"ssid"`
+
+	// ScanSSID enables scanning with SSID-specific probe requests, which
+	// is needed to find networks which don't broadcast their SSID.
+	ScanSSID bool `wpa:"scan_ssid"`
+
+	// BSSID restricts the network to a single BSS.
+	BSSID string `wpa:"bssid,raw"`
+
+	// BSSIDHint is the BSS to try first.
+	BSSIDHint string `wpa:"bssid_hint,raw"`
+
+	// BSSIDBlacklist and BSSIDWhitelist are space-separated lists of
+	// BSSIDs (optionally with "/" masks) to avoid or require.
+	BSSIDBlacklist string `wpa:"bssid_blacklist,raw"`
+	BSSIDWhitelist string `wpa:"bssid_whitelist,raw"`
+
+	// Priority is the priority group of the network.  Networks with a
+	// higher priority are preferred when selecting which network to
+	// connect to.
+	Priority int `wpa:"priority"`
+
+	// Mode is the IEEE 802.11 operation mode, e.g. ModeMesh.
+	Mode NetworkMode `wpa:"mode"`
+
+	// Frequency is the channel frequency, in Mhz, used when creating an
+	// IBSS, AP or mesh network.
+	Frequency int `wpa:"frequency"`
+
+	// ScanFreq and FreqList are space-separated lists of frequencies, in
+	// Mhz, to scan and to allow, respectively.
+	ScanFreq string `wpa:"scan_freq,raw"`
+	FreqList string `wpa:"freq_list,raw"`
+
+	// Proto is the list of accepted protocols, e.g. "RSN".
+	Proto string `wpa:"proto,raw"`
+
+	// KeyMgmt is the list of accepted key management protocols, e.g.
+	// "WPA-PSK".  If empty, wpa_supplicant's default is used.
+	KeyMgmt string `wpa:"key_mgmt,raw"`
+
+	// Pairwise, Group and GroupMgmt are the lists of accepted ciphers,
+	// e.g. "CCMP TKIP".
+	Pairwise  string `wpa:"pairwise,raw"`
+	Group     string `wpa:"group,raw"`
+	GroupMgmt string `wpa:"group_mgmt,raw"`
+
+	// AuthAlg is the list of allowed IEEE 802.11 authentication
+	// algorithms, e.g. "OPEN SHARED".
+	AuthAlg string `wpa:"auth_alg,raw"`
+
+	// PSK is the pre-shared key of a WPA-PSK network: either an ASCII
+	// passphrase, or 64 hex digits representing the 256-bit PSK.
+	PSK string `wpa:"psk,psk"`
+
+	// MemOnlyPSK keeps the PSK out of the configuration file.
+	MemOnlyPSK bool `wpa:"mem_only_psk"`
+
+	// SAEPassword is the password used for SAE (WPA3-Personal).  If
+	// empty, the PSK passphrase is used instead.
+	SAEPassword string `wpa:"sae_password,secret"`
+
+	// SAEPasswordID identifies SAEPassword to the access point.
+	SAEPasswordID string `wpa:"sae_password_id"`
+
+	// WEPKey0 through WEPKey3 are WEP keys, either ASCII or hex.
+	WEPKey0 string `wpa:"wep_key0,key"`
+	WEPKey1 string `wpa:"wep_key1,key"`
+	WEPKey2 string `wpa:"wep_key2,key"`
+	WEPKey3 string `wpa:"wep_key3,key"`
+
+	// WEPTxKeyIdx is the index of the WEP key used for transmission.
+	WEPTxKeyIdx int `wpa:"wep_tx_keyidx"`
+
+	// IEEE80211w configures protected management frames: 0 to disable, 1
+	// for optional, and 2 for required.
+	IEEE80211w int `wpa:"ieee80211w"`
+
+	// ProactiveKeyCaching enables opportunistic PMKSA caching.
+	ProactiveKeyCaching bool `wpa:"proactive_key_caching"`
+
+	// DPPConnector, DPPCSign and DPPNetAccessKey are the credentials for
+	// key_mgmt DPP, as provisioned by a DPP configurator.  The keys are
+	// hex-encoded.
+	DPPConnector    string `wpa:"dpp_connector"`
+	DPPCSign        string `wpa:"dpp_csign,raw"`
+	DPPNetAccessKey string `wpa:"dpp_netaccesskey,raw,secret"`
+
+	// EAPConfig configures IEEE 802.1X/EAP authentication.
+	EAPConfig
+
+	// IDStr is an opaque string passed to action scripts.
+	IDStr string `wpa:"id_str"`
+
+	// Disabled is 1 if the network is disabled, or 2 for a persistent
+	// P2P group.
+	Disabled int `wpa:"disabled"`
+
+	// IgnoreBroadcastSSID hides the SSID in AP mode.
+	IgnoreBroadcastSSID int `wpa:"ignore_broadcast_ssid"`
+
+	// MACAddr configures MAC address randomization: 0 to use the
+	// permanent address, 1 for a random address per association, and 2
+	// for a random address per ESS.
+	MACAddr int `wpa:"mac_addr"`
+
+	// BeaconInt and DTIMPeriod configure beacons in AP and IBSS modes.
+	BeaconInt  int `wpa:"beacon_int"`
+	DTIMPeriod int `wpa:"dtim_period"`
+
+	// Bgscan is the background scan module configuration, e.g.
+	// "simple:30:-45:300".
+	Bgscan string `wpa:"bgscan"`
+}
+
+// NetworkMode is the IEEE 802.11 operation mode of a network.
+type NetworkMode int
+
+const (
+	ModeInfrastructure    NetworkMode = 0
+	ModeIBSS              NetworkMode = 1
+	ModeAP                NetworkMode = 2
+	ModeP2PGO             NetworkMode = 3
+	ModeP2PGroupFormation NetworkMode = 4
+	ModeMesh              NetworkMode = 5
+)
+
+// fieldKind describes how a network variable is encoded in SET_NETWORK
+// commands and GET_NETWORK responses.
+type fieldKind int
+
+const (
+	// kindString is a quoted string, or hex if it isn't printable ASCII.
+	kindString fieldKind = iota
+
+	// kindRaw is an unquoted token, or list of tokens.
+	kindRaw
+
+	// kindInt is an unquoted integer.
+	kindInt
+
+	// kindBool is an unquoted 0 or 1.
+	kindBool
+
+	// kindPSK is a quoted passphrase, or an unquoted 256-bit hex key.
+	kindPSK
+
+	// kindKey is a quoted ASCII key, or an unquoted hex key.
+	kindKey
+
+	// kindPassword is a quoted password, or an unquoted password hash.
+	kindPassword
+)
+
+// networkField is a NetworkConfig field.
+type networkField struct {
+	name   string
+	kind   fieldKind
+	secret bool
+	index  []int
+}
+
+// networkFields lists the network variables in NetworkConfig, in the order
+// they are applied.
+var networkFields = parseNetworkFields(reflect.TypeOf(NetworkConfig{}))
+
+// networkFieldsByName indexes networkFields by variable name.
+var networkFieldsByName = func() map[string]networkField {
+	m := make(map[string]networkField, len(networkFields))
+	for _, f := range networkFields {
+		m[f.name] = f
+	}
+	return m
+}()
+
+func parseNetworkFields(t reflect.Type) []networkField {
+	var fields []networkField
+	for i := 0; i < t.NumField(); i++ {
+		// Embedded structs, such as EAPConfig, contribute their
+		// fields in place.
+		if t.Field(i).Anonymous {
+			for _, f := range parseNetworkFields(t.Field(i).Type) {
+				f.index = append([]int{i}, f.index...)
+				fields = append(fields, f)
+			}
+			continue
+		}
+
+		tag := strings.Split(t.Field(i).Tag.Get("wpa"), ",")
+		if tag[0] == "" {
+			continue
+		}
+
+		f := networkField{name: tag[0], index: []int{i}}
+		switch t.Field(i).Type.Kind() {
+		case reflect.Int:
+			f.kind = kindInt
+		case reflect.Bool:
+			f.kind = kindBool
+		}
+		for _, opt := range tag[1:] {
+			switch opt {
+			case "raw":
+				f.kind = kindRaw
+			case "psk":
+				f.kind, f.secret = kindPSK, true
+			case "key":
+				f.kind, f.secret = kindKey, true
+			case "password":
+				f.kind, f.secret = kindPassword, true
+			case "secret":
+				f.secret = true
+			}
+		}
+
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// get returns the field's value in cfg, which is a pointer to the struct
+// the field was parsed from, formatted as a string.  The second return
+// value is false if the field has its zero value.
+func (f networkField) get(cfg interface{}) (string, bool) {
+	switch fv := reflect.ValueOf(cfg).Elem().FieldByIndex(f.index); f.kind {
+	case kindInt:
+		return strconv.FormatInt(fv.Int(), 10), fv.Int() != 0
+	case kindBool:
+		if fv.Bool() {
+			return "1", true
+		}
+		return "0", false
+	default:
+		return fv.String(), fv.String() != ""
+	}
+}
+
+// set sets the field's value in cfg, which is a pointer to the struct the
+// field was parsed from, from its encoded form.
+func (f networkField) set(cfg interface{}, value string) error {
+	switch fv := reflect.ValueOf(cfg).Elem().FieldByIndex(f.index); f.kind {
+	case kindInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(n))
+	case kindBool:
+		fv.SetBool(value == "1")
+	case kindRaw:
+		fv.SetString(value)
+	case kindPSK, kindKey, kindPassword:
+		// Hex keys and password hashes are used as-is, not as
+		// hex-encoded strings.
+		if len(value) > 0 && value[0] == '"' {
+			value = decodeValue(value)
+		}
+		fv.SetString(value)
+	default:
+		fv.SetString(decodeValue(value))
+	}
+	return nil
+}
+
+// NetworkVariables returns the names of the network variables supported by
+// NetworkConfig, in the order ApplyNetwork() sets them.
+func NetworkVariables() []string {
+	names := make([]string, len(networkFields))
+	for i, f := range networkFields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// Get returns the value of a network variable, encoded as in
+// wpa_supplicant.conf and SET_NETWORK commands.  The second return value is
+// false if the variable is unsupported or has its zero value.
+func (cfg *NetworkConfig) Get(variable string) (string, bool) {
+	f, ok := networkFieldsByName[variable]
+	if !ok {
+		return "", false
+	}
+
+	value, ok := f.get(cfg)
+	if !ok {
+		return "", false
+	}
+	return encodeValue(variable, value), true
+}
+
+// Set sets a network variable from its encoded form, as found in
+// wpa_supplicant.conf and GET_NETWORK responses.
+func (cfg *NetworkConfig) Set(variable, value string) error {
+	f, ok := networkFieldsByName[variable]
+	if !ok {
+		return &ConfigError{variable, "is not supported"}
+	}
+
+	if err := f.set(cfg, value); err != nil {
+		return &ConfigError{variable, err.Error()}
+	}
+	return nil
+}
+
+// Redacted returns a copy of cfg with its secrets, such as the PSK and EAP
+// password, cleared.  Applying the copy leaves the secrets unchanged.
+func (cfg NetworkConfig) Redacted() NetworkConfig {
+	v := reflect.ValueOf(&cfg).Elem()
+	for _, f := range networkFields {
+		if f.secret {
+			fv := v.FieldByIndex(f.index)
+			fv.Set(reflect.Zero(fv.Type()))
+		}
+	}
+	return cfg
+}
+
+// encodeValue encodes a network variable for a SET_NETWORK command.
+// Variables we don't know about are quoted.
+func encodeValue(variable, value string) string {
+	f, ok := networkFieldsByName[variable]
+	if !ok {
+		f.kind = kindString
+	}
+	return f.encode(value)
+}
+
+// encode encodes a value of the field for SET_NETWORK and similar commands.
+func (f networkField) encode(value string) string {
+	switch f.kind {
+	case kindRaw, kindInt, kindBool:
+		return value
+	case kindPSK:
+		if len(value) == 64 && isHex(value) {
+			return value
+		}
+	case kindKey:
+		if isHex(value) {
+			return value
+		}
+	case kindPassword:
+		if strings.HasPrefix(value, "hash:") {
+			return value
+		}
+	}
+
+	// wpa_supplicant takes everything up to the last quote, so embedded
+	// quotes need no escaping.  Anything not printable can only be
+	// expressed in hex.
+	if !isPrintable(value) {
+		return hex.EncodeToString([]byte(value))
+	}
+	return `"` + value + `"`
+}
+
+// decodeValue decodes a network variable from a GET_NETWORK response or
+// wpa_supplicant.conf.
+func decodeValue(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		return value[1 : len(value)-1]
+	}
+	if len(value) >= 3 && value[0] == 'P' && value[1] == '"' && value[len(value)-1] == '"' {
+		if s, err := printfDecode(value[2 : len(value)-1]); err == nil {
+			return s
+		}
+	}
+	if b, err := hex.DecodeString(value); err == nil && len(value) > 0 {
+		return string(b)
+	}
+	return value
+}
+
+// isHex returns true if s is a non-empty string of hex digits.
+func isHex(s string) bool {
+	if len(s) == 0 || len(s)%2 != 0 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// isPrintable returns true if s only contains printable ASCII.
+func isPrintable(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks the configuration for errors wpa_supplicant would reject,
+// or which would prevent connecting to the network.
+func (cfg *NetworkConfig) Validate() error {
+	if cfg.PSK != "" && !(len(cfg.PSK) == 64 && isHex(cfg.PSK)) {
+		if err := ValidatePassphrase(cfg.PSK); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Mode == ModeMesh {
+		if cfg.Frequency == 0 {
+			return &ConfigError{"frequency", "is required for mesh networks"}
+		}
+		if cfg.KeyMgmt != "SAE" && cfg.KeyMgmt != "NONE" {
+			return &ConfigError{"key_mgmt", "must be SAE or NONE for mesh networks"}
+		}
+	}
+
+	return cfg.EAPConfig.Validate()
+}
+
+// applyNetwork configures the network identified by networkID using the
+// non-zero fields of cfg.
+func applyNetwork(c Conn, networkID int, cfg NetworkConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	for _, f := range networkFields {
+		value, ok := f.get(&cfg)
+		if !ok {
+			continue
+		}
+
+		if err := c.SetNetwork(networkID, f.name, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ErrNetworkNotFound is returned when reading a network which doesn't
+// exist.
+var ErrNetworkNotFound = errors.New("network not found")
+
+// readNetwork reads the configuration of the network identified by
+// networkID.  Secrets (such as the PSK) cannot be read back, and are left
+// empty.
+func readNetwork(c Conn, networkID int) (NetworkConfig, error) {
+	var cfg NetworkConfig
+	for _, f := range networkFields {
+		resp, err := c.GetNetwork(networkID, f.name)
+		if err != nil {
+			return cfg, err
+		}
+
+		// Unset variables (and those not supported by this build of
+		// wpa_supplicant) return FAIL.  Secrets return "*".
+		resp = strings.TrimSuffix(resp, "\n")
+		if resp == "FAIL" && f.name == "ssid" {
+			// GET_NETWORK also returns FAIL if the network
+			// doesn't exist.
+			if err = checkNetworkExists(c, networkID); err != nil {
+				return cfg, err
+			}
+		}
+		if resp == "FAIL" || resp == "" || (f.secret && resp == "*") {
+			continue
+		}
+
+		if err = f.set(&cfg, resp); err != nil {
+			return cfg, &ParseError{Line: resp, Err: err}
+		}
+	}
+
+	return cfg, nil
+}
+
+// checkNetworkExists returns ErrNetworkNotFound if networkID isn't in the
+// list of configured networks.
+func checkNetworkExists(c Conn, networkID int) error {
+	networks, err := c.ListNetworks()
+	if err != nil {
+		return err
+	}
+	for _, n := range networks {
+		if n.NetworkID() == networkID {
+			return nil
+		}
+	}
+	return ErrNetworkNotFound
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"strings"
+	"testing"
+)
+
+var encodeValueTests = []struct {
+	variable, value, expect string
+}{
+	{"ssid", "test network", `"test network"`},
+	{"ssid", `say "hi"`, `"say "hi""`},
+	{"ssid", "caf\xc3\xa9", "636166c3a9"},
+	{"scan_ssid", "1", "1"},
+	{"key_mgmt", "WPA-PSK SAE", "WPA-PSK SAE"},
+	{"priority", "5", "5"},
+	{"ieee80211w", "2", "2"},
+	{"psk", "password", `"password"`},
+	{"psk", strings.Repeat("0f", 32), strings.Repeat("0f", 32)},
+	{"psk", "abcdef12", `"abcdef12"`},
+	{"wep_key0", "0102030405", "0102030405"},
+	{"wep_key0", "abcde", `"abcde"`},
+	{"identity", "user@example.com", `"user@example.com"`},
+	{"unknown_variable", "foo", `"foo"`},
+}
+
+func TestEncodeValue(t *testing.T) {
+	for _, test := range encodeValueTests {
+		if got := encodeValue(test.variable, test.value); got != test.expect {
+			t.Errorf("wrong encoding of %s %q (got %s, expect %s)", test.variable, test.value, got, test.expect)
+		}
+	}
+}
+
+func TestApplyNetwork(t *testing.T) {
+	fs, conn := newFakeServer(t, nil)
+
+	err := conn.ApplyNetwork(3, NetworkConfig{
+		SSID:       "test",
+		ScanSSID:   true,
+		KeyMgmt:    "WPA-PSK",
+		Proto:      "RSN",
+		Pairwise:   "CCMP",
+		PSK:        "password",
+		IEEE80211w: 1,
+		Priority:   10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs.expectCommands(
+		`SET_NETWORK 3 ssid "test"`,
+		"SET_NETWORK 3 scan_ssid 1",
+		"SET_NETWORK 3 priority 10",
+		"SET_NETWORK 3 proto RSN",
+		"SET_NETWORK 3 key_mgmt WPA-PSK",
+		"SET_NETWORK 3 pairwise CCMP",
+		`SET_NETWORK 3 psk "password"`,
+		"SET_NETWORK 3 ieee80211w 1",
+	)
+}
+
+func TestReadNetwork(t *testing.T) {
+	values := map[string]string{
+		"ssid":      "74657374",
+		"scan_ssid": "1",
+		"priority":  "10",
+		"key_mgmt":  "WPA-PSK",
+		"psk":       "*",
+		"id_str":    `"home"`,
+		"disabled":  "0",
+	}
+
+	_, conn := newFakeServer(t, func(cmd string) string {
+		if cmd == "LIST_NETWORKS" {
+			return "network id / ssid / bssid / flags\n0\ttest\tany\t\n"
+		}
+		fields := strings.Fields(cmd)
+		if len(fields) != 3 || fields[0] != "GET_NETWORK" {
+			return ""
+		}
+		if v, ok := values[fields[2]]; ok && fields[1] == "0" {
+			return v
+		}
+		return "FAIL\n"
+	})
+
+	cfg, err := conn.ReadNetwork(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := NetworkConfig{
+		SSID:     "test",
+		ScanSSID: true,
+		Priority: 10,
+		KeyMgmt:  "WPA-PSK",
+		IDStr:    "home",
+	}
+	if cfg != expect {
+		t.Errorf("got %+v, expected %+v", cfg, expect)
+	}
+
+	if _, err = conn.ReadNetwork(999); err != ErrNetworkNotFound {
+		t.Errorf("expected ErrNetworkNotFound, got %v", err)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := NetworkConfig{
+		SSID:        "home",
+		PSK:         "correct horse",
+		SAEPassword: "battery staple",
+		WEPKey0:     "abcde",
+		EAPConfig: EAPConfig{
+			Identity:         "user",
+			Password:         "secret",
+			PrivateKeyPasswd: "secret",
+		},
+	}
+
+	r := cfg.Redacted()
+	if r.SSID != "home" || r.Identity != "user" {
+		t.Errorf("redaction removed non-secret fields: %+v", r)
+	}
+	if r.PSK != "" || r.SAEPassword != "" || r.WEPKey0 != "" || r.Password != "" || r.PrivateKeyPasswd != "" {
+		t.Errorf("secrets not redacted: %+v", r)
+	}
+	if cfg.PSK == "" {
+		t.Error("Redacted modified the original")
+	}
+}
@@ -0,0 +1,576 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// P2PInterface returns the name of the control interface socket to use for
+// P2P commands on the specified interface.  Drivers which support a
+// dedicated P2P Device have a separate p2p-dev-<ifName> socket, which is
+// returned if it exists in dir; otherwise P2P is controlled using the
+// interface itself.  Pass the result to UnixgramDir().
+func P2PInterface(dir, ifName string) string {
+	if _, err := os.Stat(path.Join(dir, "p2p-dev-"+ifName)); err == nil {
+		return "p2p-dev-" + ifName
+	}
+	return ifName
+}
+
+// P2P controls Wi-Fi Direct (P2P) using a Conn.  The Conn should be
+// connected to the interface returned by P2PInterface(), and its EventQueue
+// receives the P2P events, such as P2P-DEVICE-FOUND.
+type P2P struct {
+	c Conn
+}
+
+// NewP2P returns a P2P which sends commands using the specified Conn.
+func NewP2P(c Conn) *P2P {
+	return &P2P{c: c}
+}
+
+// Find starts searching for P2P devices, sending P2P-DEVICE-FOUND events
+// for each one found.  The search stops after timeout seconds, or when
+// StopFind() is called if timeout is 0.
+func (p *P2P) Find(timeout int) error {
+	if timeout == 0 {
+		return runOK(p.c, "P2P_FIND")
+	}
+	return runOK(p.c, fmt.Sprintf("P2P_FIND %d", timeout))
+}
+
+// StopFind stops searching for P2P devices.
+func (p *P2P) StopFind() error {
+	return runOK(p.c, "P2P_STOP_FIND")
+}
+
+// Peers returns the P2P device addresses of the peers found so far, like
+// wpa_cli's p2p_peers command.
+func (p *P2P) Peers() ([]net.HardwareAddr, error) {
+	var peers []net.HardwareAddr
+
+	cmd := "P2P_PEER FIRST"
+	for {
+		resp, err := p.c.Command(cmd)
+		if err != nil {
+			return peers, err
+		}
+
+		// The first line of the response is the peer's address.
+		// FAIL means there are no more peers.
+		line := strings.SplitN(resp, "\n", 2)[0]
+		if line == "FAIL" || line == "" {
+			return peers, nil
+		}
+
+		addr, err := net.ParseMAC(line)
+		if err != nil {
+			return peers, &ParseError{Line: line, Err: err}
+		}
+		peers = append(peers, addr)
+		cmd = "P2P_PEER NEXT-" + addr.String()
+	}
+}
+
+// P2PPeer describes a P2P device found by P2P.Find().
+type P2PPeer interface {
+	// Address is the P2P device address of the peer.
+	Address() net.HardwareAddr
+
+	// Name is the device name of the peer.
+	Name() string
+
+	// DeviceType is the primary device type, e.g. "1-0050F204-1".
+	DeviceType() string
+
+	Manufacturer() string
+	ModelName() string
+	ModelNumber() string
+	SerialNumber() string
+
+	// ConfigMethods is the bitmask of supported WPS configuration
+	// methods.
+	ConfigMethods() int
+
+	// DeviceCapability and GroupCapability are the P2P capability
+	// bitmasks of the peer.
+	DeviceCapability() int
+	GroupCapability() int
+
+	// Level is the received signal strength of the peer, in dBm.
+	Level() int
+}
+
+type p2pPeer struct {
+	address                                          net.HardwareAddr
+	name, deviceType                                 string
+	manufacturer, modelName, modelNumber, serial     string
+	configMethods, deviceCapability, groupCapability int
+	level                                            int
+}
+
+func (r *p2pPeer) Address() net.HardwareAddr { return r.address }
+func (r *p2pPeer) Name() string              { return r.name }
+func (r *p2pPeer) DeviceType() string        { return r.deviceType }
+func (r *p2pPeer) Manufacturer() string      { return r.manufacturer }
+func (r *p2pPeer) ModelName() string         { return r.modelName }
+func (r *p2pPeer) ModelNumber() string       { return r.modelNumber }
+func (r *p2pPeer) SerialNumber() string      { return r.serial }
+func (r *p2pPeer) ConfigMethods() int        { return r.configMethods }
+func (r *p2pPeer) DeviceCapability() int     { return r.deviceCapability }
+func (r *p2pPeer) GroupCapability() int      { return r.groupCapability }
+func (r *p2pPeer) Level() int                { return r.level }
+
+// Peer returns information about the peer with the specified P2P device
+// address.
+func (p *P2P) Peer(addr net.HardwareAddr) (P2PPeer, error) {
+	resp, err := p.c.Command("P2P_PEER " + addr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return parseP2PPeer(resp)
+}
+
+// parseP2PPeer parses a P2P_PEER response, which is the peer's address
+// followed by key=value lines.
+func parseP2PPeer(resp string) (P2PPeer, error) {
+	s := bufio.NewScanner(strings.NewReader(resp))
+	if !s.Scan() {
+		return nil, &ParseError{Line: resp}
+	}
+
+	addr, err := net.ParseMAC(s.Text())
+	if err != nil {
+		return nil, &ParseError{Line: s.Text(), Err: err}
+	}
+	peer := &p2pPeer{address: addr}
+
+	for s.Scan() {
+		kv := strings.SplitN(s.Text(), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "device_name":
+			peer.name = kv[1]
+		case "pri_dev_type":
+			peer.deviceType = kv[1]
+		case "manufacturer":
+			peer.manufacturer = kv[1]
+		case "model_name":
+			peer.modelName = kv[1]
+		case "model_number":
+			peer.modelNumber = kv[1]
+		case "serial_number":
+			peer.serial = kv[1]
+		case "config_methods":
+			peer.configMethods = parseP2PInt(kv[1])
+		case "dev_capab":
+			peer.deviceCapability = parseP2PInt(kv[1])
+		case "group_capab":
+			peer.groupCapability = parseP2PInt(kv[1])
+		case "level":
+			peer.level = parseP2PInt(kv[1])
+		}
+	}
+
+	return peer, nil
+}
+
+// parseP2PInt parses a decimal or 0x-prefixed hex integer, returning 0 if it
+// can't be parsed.
+func parseP2PInt(s string) int {
+	n, _ := strconv.ParseInt(s, 0, 64)
+	return int(n)
+}
+
+// P2PConnectOptions modifies the behavior of P2P.Connect().
+type P2PConnectOptions struct {
+	// PIN is the WPS PIN to use for provisioning, or "pin" to generate
+	// one.  If empty, push-button provisioning is used.
+	PIN string
+
+	// Display means the PIN is shown on this device and entered on the
+	// peer, rather than the other way around.
+	Display bool
+
+	// GOIntent is the Group Owner intent, from 0 to 15.  Higher values
+	// prefer becoming the group owner.  If nil, the p2p_go_intent
+	// setting in wpa_supplicant.conf is used.
+	GOIntent *int
+
+	// Frequency is the frequency, in Mhz, to use for the group.
+	Frequency int
+
+	// Persistent creates a persistent group, which can be restarted
+	// later without provisioning.
+	Persistent bool
+
+	// Join joins a group the peer is already running, rather than
+	// negotiating a new one.
+	Join bool
+}
+
+// Connect starts group owner negotiation and provisioning with a peer.  The
+// result is reported by P2P-GO-NEG-SUCCESS and P2P-GROUP-STARTED events, or
+// P2P-GO-NEG-FAILURE.  If opts.PIN is "pin", the generated PIN is returned.
+func (p *P2P) Connect(peer net.HardwareAddr, opts *P2PConnectOptions) (string, error) {
+	if opts == nil {
+		opts = &P2PConnectOptions{}
+	}
+
+	args := []string{"P2P_CONNECT", peer.String()}
+	switch {
+	case opts.PIN == "":
+		args = append(args, "pbc")
+	case opts.Display:
+		args = append(args, opts.PIN, "display")
+	default:
+		args = append(args, opts.PIN, "keypad")
+	}
+	if opts.Persistent {
+		args = append(args, "persistent")
+	}
+	if opts.Join {
+		args = append(args, "join")
+	}
+	if opts.GOIntent != nil {
+		args = append(args, fmt.Sprintf("go_intent=%d", *opts.GOIntent))
+	}
+	if opts.Frequency != 0 {
+		args = append(args, fmt.Sprintf("freq=%d", opts.Frequency))
+	}
+
+	resp, err := p.c.Command(strings.Join(args, " "))
+	if err != nil {
+		return "", err
+	}
+
+	switch resp = strings.TrimSpace(resp); {
+	case resp == "OK":
+		return "", nil
+	case resp == "" || strings.HasPrefix(resp, "FAIL"):
+		return "", &ParseError{Line: resp}
+	}
+	return resp, nil
+}
+
+// Cancel cancels an ongoing P2P.Connect().
+func (p *P2P) Cancel() error {
+	return runOK(p.c, "P2P_CANCEL")
+}
+
+// GroupAdd starts a P2P group with this device as the group owner,
+// optionally on a specific frequency, in Mhz.
+func (p *P2P) GroupAdd(persistent bool, freq int) error {
+	cmd := "P2P_GROUP_ADD"
+	if persistent {
+		cmd += " persistent"
+	}
+	if freq != 0 {
+		cmd += fmt.Sprintf(" freq=%d", freq)
+	}
+	return runOK(p.c, cmd)
+}
+
+// GroupRestart restarts the persistent group stored as the specified
+// network.
+func (p *P2P) GroupRestart(networkID int) error {
+	return runOK(p.c, fmt.Sprintf("P2P_GROUP_ADD persistent=%d", networkID))
+}
+
+// GroupRemove removes the group running on the specified interface, as
+// reported in the P2P-GROUP-STARTED event.
+func (p *P2P) GroupRemove(ifName string) error {
+	return runOK(p.c, "P2P_GROUP_REMOVE "+ifName)
+}
+
+// Invite invites a peer to join the group running on the specified
+// interface.
+func (p *P2P) Invite(peer net.HardwareAddr, ifName string) error {
+	return runOK(p.c, fmt.Sprintf("P2P_INVITE group=%s peer=%s", ifName, peer))
+}
+
+// InvitePersistent invites a peer to restart the persistent group stored as
+// the specified network.
+func (p *P2P) InvitePersistent(peer net.HardwareAddr, networkID int) error {
+	return runOK(p.c, fmt.Sprintf("P2P_INVITE persistent=%d peer=%s", networkID, peer))
+}
+
+// splitEventFields splits an event line on spaces, keeping quoted values
+// such as name='Wireless Client' together and removing their quotes.
+// Backslash escapes within quotes are kept, for printfDecode().
+func splitEventFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	var quote rune
+	inField, escaped := false, false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case quote != 0 && r == '\\':
+			field.WriteRune(r)
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				field.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inField = r, true
+		case r == ' ':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+
+	return fields
+}
+
+// eventArgs returns the key=value fields of an event line, and the other
+// fields, excluding the event name.
+func eventArgs(line string) (args map[string]string, other []string) {
+	args = make(map[string]string)
+	fields := splitEventFields(line)
+	if len(fields) == 0 {
+		return
+	}
+
+	for _, f := range fields[1:] {
+		if kv := strings.SplitN(f, "=", 2); len(kv) == 2 {
+			args[kv[0]] = kv[1]
+		} else {
+			other = append(other, f)
+		}
+	}
+	return
+}
+
+// P2PDeviceFound is a P2P-DEVICE-FOUND event, which looks like:
+//
+//	P2P-DEVICE-FOUND 02:00:00:00:01:00 p2p_dev_addr=02:00:00:00:01:00 pri_dev_type=1-0050F204-1 name='Wireless Client' config_methods=0x188 dev_capab=0x25 group_capab=0x0
+type P2PDeviceFound struct {
+	// Address is the P2P device address of the peer.
+	Address net.HardwareAddr
+
+	// Name is the device name of the peer.
+	Name string
+
+	// DeviceType is the primary device type, e.g. "1-0050F204-1".
+	DeviceType string
+
+	// ConfigMethods is the bitmask of supported WPS configuration
+	// methods.
+	ConfigMethods int
+
+	// DeviceCapability and GroupCapability are the P2P capability
+	// bitmasks of the peer.
+	DeviceCapability, GroupCapability int
+}
+
+// ParseP2PDeviceFound returns the P2P-DEVICE-FOUND event described by ev.
+// The second return value is false if ev isn't a P2P-DEVICE-FOUND event.
+func ParseP2PDeviceFound(ev WPAEvent) (*P2PDeviceFound, bool) {
+	if ev.Event != "P2P-DEVICE-FOUND" {
+		return nil, false
+	}
+
+	args, _ := eventArgs(ev.Line)
+	addr, err := net.ParseMAC(args["p2p_dev_addr"])
+	if err != nil {
+		return nil, false
+	}
+
+	return &P2PDeviceFound{
+		Address:          addr,
+		Name:             args["name"],
+		DeviceType:       args["pri_dev_type"],
+		ConfigMethods:    parseP2PInt(args["config_methods"]),
+		DeviceCapability: parseP2PInt(args["dev_capab"]),
+		GroupCapability:  parseP2PInt(args["group_capab"]),
+	}, true
+}
+
+// P2PGoNegRequest is a P2P-GO-NEG-REQUEST event, sent when a peer wants to
+// connect.  Accept it using P2P.Connect().  It looks like:
+//
+//	P2P-GO-NEG-REQUEST 02:00:00:00:01:00 dev_passwd_id=4 go_intent=7
+type P2PGoNegRequest struct {
+	// Peer is the P2P device address of the peer.
+	Peer net.HardwareAddr
+
+	// DevicePasswordID is the WPS device password ID requested by the
+	// peer: 0 for a PIN, 4 for push-button, 1 if the peer will enter a
+	// PIN displayed by this device, or 5 if it will display one.
+	DevicePasswordID int
+
+	// GOIntent is the peer's Group Owner intent.
+	GOIntent int
+}
+
+// ParseP2PGoNegRequest returns the P2P-GO-NEG-REQUEST event described by
+// ev.  The second return value is false if ev isn't a P2P-GO-NEG-REQUEST
+// event.
+func ParseP2PGoNegRequest(ev WPAEvent) (*P2PGoNegRequest, bool) {
+	if ev.Event != "P2P-GO-NEG-REQUEST" {
+		return nil, false
+	}
+
+	args, other := eventArgs(ev.Line)
+	if len(other) == 0 {
+		return nil, false
+	}
+	peer, err := net.ParseMAC(other[0])
+	if err != nil {
+		return nil, false
+	}
+
+	return &P2PGoNegRequest{
+		Peer:             peer,
+		DevicePasswordID: parseP2PInt(args["dev_passwd_id"]),
+		GOIntent:         parseP2PInt(args["go_intent"]),
+	}, true
+}
+
+// P2PGroupStarted is a P2P-GROUP-STARTED event, which looks like:
+//
+//	P2P-GROUP-STARTED p2p-wlan0-0 GO ssid="DIRECT-ab" freq=2412 passphrase="12345678" go_dev_addr=02:00:00:00:00:01 [PERSISTENT]
+type P2PGroupStarted struct {
+	// Interface is the network interface of the group.  Use it to
+	// connect to the group's control interface, and with
+	// P2P.GroupRemove().
+	Interface string
+
+	// GO is true if this device is the group owner, and false if it is a
+	// client.
+	GO bool
+
+	// SSID is the raw SSID of the group.
+	SSID string
+
+	// Frequency is the operating frequency of the group, in Mhz.
+	Frequency int
+
+	// Passphrase and PSK are the group's credentials.  The passphrase
+	// is only known to the group owner; clients receive the PSK, in hex.
+	Passphrase, PSK string
+
+	// GODeviceAddress is the P2P device address of the group owner.
+	GODeviceAddress net.HardwareAddr
+
+	// Persistent is true if the group is persistent.
+	Persistent bool
+}
+
+// ParseP2PGroupStarted returns the P2P-GROUP-STARTED event described by ev.
+// The second return value is false if ev isn't a P2P-GROUP-STARTED event.
+func ParseP2PGroupStarted(ev WPAEvent) (*P2PGroupStarted, bool) {
+	if ev.Event != "P2P-GROUP-STARTED" {
+		return nil, false
+	}
+
+	args, other := eventArgs(ev.Line)
+	if len(other) < 2 {
+		return nil, false
+	}
+
+	g := &P2PGroupStarted{
+		Interface:  other[0],
+		GO:         other[1] == "GO",
+		Frequency:  parseP2PInt(args["freq"]),
+		Passphrase: args["passphrase"],
+		PSK:        args["psk"],
+	}
+	if ssid, err := printfDecode(args["ssid"]); err == nil {
+		g.SSID = ssid
+	}
+	g.GODeviceAddress, _ = net.ParseMAC(args["go_dev_addr"])
+	for _, f := range other[2:] {
+		if f == "[PERSISTENT]" {
+			g.Persistent = true
+		}
+	}
+	return g, true
+}
+
+// P2PGroupRemoved is a P2P-GROUP-REMOVED event, which looks like:
+//
+//	P2P-GROUP-REMOVED p2p-wlan0-0 GO reason=REQUESTED
+type P2PGroupRemoved struct {
+	// Interface is the network interface of the group.
+	Interface string
+
+	// GO is true if this device was the group owner.
+	GO bool
+
+	// Reason is why the group was removed, e.g. "REQUESTED" or
+	// "IDLE".
+	Reason string
+}
+
+// ParseP2PGroupRemoved returns the P2P-GROUP-REMOVED event described by ev.
+// The second return value is false if ev isn't a P2P-GROUP-REMOVED event.
+func ParseP2PGroupRemoved(ev WPAEvent) (*P2PGroupRemoved, bool) {
+	if ev.Event != "P2P-GROUP-REMOVED" {
+		return nil, false
+	}
+
+	args, other := eventArgs(ev.Line)
+	if len(other) < 2 {
+		return nil, false
+	}
+
+	return &P2PGroupRemoved{
+		Interface: other[0],
+		GO:        other[1] == "GO",
+		Reason:    args["reason"],
+	}, true
+}
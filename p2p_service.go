@@ -0,0 +1,517 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// P2PServiceProtocol is the service protocol type of a P2P service
+// discovery TLV.
+type P2PServiceProtocol byte
+
+const (
+	P2PServiceAll         P2PServiceProtocol = 0
+	P2PServiceBonjour     P2PServiceProtocol = 1
+	P2PServiceUPnP        P2PServiceProtocol = 2
+	P2PServiceWSDiscovery P2PServiceProtocol = 3
+	P2PServiceWiFiDisplay P2PServiceProtocol = 4
+)
+
+// P2PServiceStatus is the status code of a P2P service discovery response.
+type P2PServiceStatus byte
+
+const (
+	P2PServiceSuccess               P2PServiceStatus = 0
+	P2PServiceProtocolNotAvailable  P2PServiceStatus = 1
+	P2PServiceQueryDataNotAvailable P2PServiceStatus = 2
+	P2PServiceBadRequest            P2PServiceStatus = 3
+)
+
+// P2PServiceTLV is a P2P service discovery query or response.
+type P2PServiceTLV struct {
+	// Protocol is the service protocol the TLV applies to.
+	Protocol P2PServiceProtocol
+
+	// TransactionID matches responses to queries.
+	TransactionID byte
+
+	// Status is the result of a query.  It is only used in responses.
+	Status P2PServiceStatus
+
+	// Data is the protocol-specific query or response data, e.g. from
+	// BonjourQuery() or UPnPQuery().
+	Data []byte
+}
+
+// EncodeP2PServiceQueries encodes query TLVs for
+// P2P.ServiceDiscoveryRequest().
+func EncodeP2PServiceQueries(tlvs ...P2PServiceTLV) []byte {
+	var b []byte
+	for _, t := range tlvs {
+		b = binary.LittleEndian.AppendUint16(b, uint16(2+len(t.Data)))
+		b = append(b, byte(t.Protocol), t.TransactionID)
+		b = append(b, t.Data...)
+	}
+	return b
+}
+
+// EncodeP2PServiceResponses encodes response TLVs for
+// P2P.ServiceDiscoveryResponse().
+func EncodeP2PServiceResponses(tlvs ...P2PServiceTLV) []byte {
+	var b []byte
+	for _, t := range tlvs {
+		b = binary.LittleEndian.AppendUint16(b, uint16(3+len(t.Data)))
+		b = append(b, byte(t.Protocol), t.TransactionID, byte(t.Status))
+		b = append(b, t.Data...)
+	}
+	return b
+}
+
+// decodeP2PServiceTLVs decodes query or response TLVs.
+func decodeP2PServiceTLVs(b []byte, response bool) ([]P2PServiceTLV, error) {
+	header := 2
+	if response {
+		header = 3
+	}
+
+	var tlvs []P2PServiceTLV
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return tlvs, errors.New("truncated service discovery TLV")
+		}
+		l := int(binary.LittleEndian.Uint16(b))
+		if l < header || len(b) < 2+l {
+			return tlvs, errors.New("truncated service discovery TLV")
+		}
+
+		t := P2PServiceTLV{
+			Protocol:      P2PServiceProtocol(b[2]),
+			TransactionID: b[3],
+		}
+		if response {
+			t.Status = P2PServiceStatus(b[4])
+		}
+		t.Data = b[2+header : 2+l]
+		tlvs = append(tlvs, t)
+		b = b[2+l:]
+	}
+	return tlvs, nil
+}
+
+// DNS resource record types used with Bonjour.
+const (
+	DNSTypePTR = 12
+	DNSTypeTXT = 16
+)
+
+// bonjourDictionary lists the DNS name suffixes which the Wi-Fi P2P
+// specification compresses to fixed offsets.
+var bonjourDictionary = []struct {
+	suffix string
+	ptr    uint16
+}{
+	{"_tcp.local.", 0xc00c},
+	{"local.", 0xc011},
+	{"_udp.local.", 0xc01c},
+}
+
+// bonjourInstancePtr is the compression pointer used in PTR record data to
+// refer to the query name.
+const bonjourInstancePtr = 0xc027
+
+// encodeBonjourName encodes a DNS name, compressing its suffix using the
+// P2P dictionary.
+func encodeBonjourName(name string) []byte {
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+
+	var b []byte
+	for name != "" {
+		for _, d := range bonjourDictionary {
+			if name == d.suffix {
+				return binary.BigEndian.AppendUint16(b, d.ptr)
+			}
+		}
+
+		label := name
+		if i := strings.Index(name, "."); i >= 0 {
+			label, name = name[:i], name[i+1:]
+		} else {
+			name = ""
+		}
+		b = append(b, byte(len(label)))
+		b = append(b, label...)
+	}
+	return append(b, 0)
+}
+
+// decodeBonjourName decodes a DNS name encoded by encodeBonjourName(),
+// returning the remaining data.  query is the name substituted for the
+// instance pointer in PTR data.
+func decodeBonjourName(b []byte, query string) (string, []byte, error) {
+	var name string
+	for {
+		if len(b) == 0 {
+			return "", nil, errors.New("truncated DNS name")
+		}
+
+		switch {
+		case b[0] == 0:
+			return name, b[1:], nil
+
+		case b[0]&0xc0 == 0xc0:
+			if len(b) < 2 {
+				return "", nil, errors.New("truncated DNS name")
+			}
+			ptr := binary.BigEndian.Uint16(b)
+			if ptr == bonjourInstancePtr && query != "" {
+				return name + query, b[2:], nil
+			}
+			for _, d := range bonjourDictionary {
+				if ptr == d.ptr {
+					return name + d.suffix, b[2:], nil
+				}
+			}
+			return "", nil, fmt.Errorf("unknown DNS name pointer %#x", ptr)
+
+		default:
+			l := int(b[0])
+			if len(b) < 1+l {
+				return "", nil, errors.New("truncated DNS name")
+			}
+			name += string(b[1:1+l]) + "."
+			b = b[1+l:]
+		}
+	}
+}
+
+// BonjourQuery returns the query data for a Bonjour service, e.g.
+// BonjourQuery("_ipp._tcp.local.", DNSTypePTR) to find printers.  It is used
+// both in service discovery requests and with P2P.ServiceAddBonjour().
+func BonjourQuery(name string, dnsType uint16) []byte {
+	b := encodeBonjourName(name)
+	b = binary.BigEndian.AppendUint16(b, dnsType)
+	return append(b, 1) // version
+}
+
+// BonjourPTRData returns the record data of a PTR record for the specified
+// service instance, for use with P2P.ServiceAddBonjour().
+func BonjourPTRData(instance string) []byte {
+	b := []byte{byte(len(instance))}
+	b = append(b, instance...)
+	return binary.BigEndian.AppendUint16(b, bonjourInstancePtr)
+}
+
+// BonjourTXTData returns the record data of a TXT record with the specified
+// strings, e.g. "txtvers=1", for use with P2P.ServiceAddBonjour().
+func BonjourTXTData(txt ...string) []byte {
+	if len(txt) == 0 {
+		return []byte{0}
+	}
+
+	var b []byte
+	for _, s := range txt {
+		b = append(b, byte(len(s)))
+		b = append(b, s...)
+	}
+	return b
+}
+
+// BonjourRecord is a Bonjour record from a service discovery response.
+type BonjourRecord struct {
+	// Name is the record's DNS name, e.g. "_ipp._tcp.local.".
+	Name string
+
+	// Type is the DNS record type, e.g. DNSTypePTR.
+	Type uint16
+
+	// Instance is the service instance name from a PTR record, e.g.
+	// "myprinter._ipp._tcp.local.".
+	Instance string
+
+	// TXT is the strings of a TXT record.
+	TXT []string
+
+	// Data is the raw record data.
+	Data []byte
+}
+
+// Bonjour decodes a Bonjour response TLV.  The second return value is false
+// if t isn't a successful Bonjour response, or can't be decoded.
+func (t *P2PServiceTLV) Bonjour() (*BonjourRecord, bool) {
+	if t.Protocol != P2PServiceBonjour || t.Status != P2PServiceSuccess {
+		return nil, false
+	}
+
+	name, b, err := decodeBonjourName(t.Data, "")
+	if err != nil || len(b) < 3 {
+		return nil, false
+	}
+
+	r := &BonjourRecord{
+		Name: name,
+		Type: binary.BigEndian.Uint16(b),
+		Data: b[3:],
+	}
+
+	switch r.Type {
+	case DNSTypePTR:
+		if r.Instance, _, err = decodeBonjourName(r.Data, name); err != nil {
+			return nil, false
+		}
+	case DNSTypeTXT:
+		for d := r.Data; len(d) > 0; {
+			l := int(d[0])
+			if len(d) < 1+l {
+				return nil, false
+			}
+			if l > 0 {
+				r.TXT = append(r.TXT, string(d[1:1+l]))
+			}
+			d = d[1+l:]
+		}
+	}
+
+	return r, true
+}
+
+// UPnPQuery returns the query data for UPnP services matching a search
+// target, e.g. "ssdp:all" or "upnp:rootdevice".  Version is the UPnP
+// version, e.g. 0x10 for 1.0.
+func UPnPQuery(version byte, searchTarget string) []byte {
+	return append([]byte{version}, searchTarget...)
+}
+
+// UPnPRecord is the list of UPnP services from a service discovery
+// response.
+type UPnPRecord struct {
+	// Version is the UPnP version, e.g. 0x10 for 1.0.
+	Version byte
+
+	// Services are the unique service names (USNs) of the services, e.g.
+	// "uuid:6859dede-8574-59ab-9332-123456789012::upnp:rootdevice".
+	Services []string
+}
+
+// UPnP decodes a UPnP response TLV.  The second return value is false if t
+// isn't a successful UPnP response.
+func (t *P2PServiceTLV) UPnP() (*UPnPRecord, bool) {
+	if t.Protocol != P2PServiceUPnP || t.Status != P2PServiceSuccess || len(t.Data) == 0 {
+		return nil, false
+	}
+
+	r := &UPnPRecord{Version: t.Data[0]}
+	for _, s := range strings.Split(string(t.Data[1:]), ",") {
+		if s != "" {
+			r.Services = append(r.Services, s)
+		}
+	}
+	return r, true
+}
+
+// ServiceAddBonjour advertises a Bonjour record, identified by its query
+// data, e.g. from BonjourQuery() and BonjourPTRData().
+func (p *P2P) ServiceAddBonjour(query, data []byte) error {
+	return runOK(p.c, fmt.Sprintf("P2P_SERVICE_ADD bonjour %x %x", query, data))
+}
+
+// ServiceDelBonjour removes a Bonjour record added with ServiceAddBonjour().
+func (p *P2P) ServiceDelBonjour(query []byte) error {
+	return runOK(p.c, fmt.Sprintf("P2P_SERVICE_DEL bonjour %x", query))
+}
+
+// ServiceAddUPnP advertises a UPnP service, identified by its USN.
+func (p *P2P) ServiceAddUPnP(version byte, service string) error {
+	return runOK(p.c, fmt.Sprintf("P2P_SERVICE_ADD upnp %02x %s", version, service))
+}
+
+// ServiceDelUPnP removes a UPnP service added with ServiceAddUPnP().
+func (p *P2P) ServiceDelUPnP(version byte, service string) error {
+	return runOK(p.c, fmt.Sprintf("P2P_SERVICE_DEL upnp %02x %s", version, service))
+}
+
+// ServiceFlush removes all advertised services.
+func (p *P2P) ServiceFlush() error {
+	return runOK(p.c, "P2P_SERVICE_FLUSH")
+}
+
+// ServiceDiscoveryRequest schedules a service discovery request, encoded by
+// EncodeP2PServiceQueries(), to the specified peer, or all peers if peer is
+// nil.  Requests are sent during P2P.Find(), and responses are reported in
+// P2P-SERV-DISC-RESP events.  Returns an ID which can be passed to
+// CancelServiceDiscoveryRequest().
+func (p *P2P) ServiceDiscoveryRequest(peer net.HardwareAddr, queries []byte) (string, error) {
+	if peer == nil {
+		peer = net.HardwareAddr{0, 0, 0, 0, 0, 0}
+	}
+
+	resp, err := p.c.Command(fmt.Sprintf("P2P_SERV_DISC_REQ %s %x", peer, queries))
+	if err != nil {
+		return "", err
+	}
+
+	if resp = strings.TrimSpace(resp); resp == "" || strings.HasPrefix(resp, "FAIL") {
+		return "", &ParseError{Line: resp}
+	}
+	return resp, nil
+}
+
+// CancelServiceDiscoveryRequest cancels a request scheduled by
+// ServiceDiscoveryRequest().
+func (p *P2P) CancelServiceDiscoveryRequest(id string) error {
+	return runOK(p.c, "P2P_SERV_DISC_CANCEL_REQ "+id)
+}
+
+// ServiceDiscoveryResponse answers a P2P-SERV-DISC-REQ event with responses
+// encoded by EncodeP2PServiceResponses().  This is only needed if
+// wpa_supplicant is configured to pass requests to the application, using
+// P2P_SERV_DISC_EXTERNAL.
+func (p *P2P) ServiceDiscoveryResponse(req *P2PServiceDiscoveryRequest, responses []byte) error {
+	return runOK(p.c, fmt.Sprintf("P2P_SERV_DISC_RESP %d %s %d %x",
+		req.Frequency, req.Peer, req.DialogToken, responses))
+}
+
+// P2PServiceDiscoveryRequest is a P2P-SERV-DISC-REQ event, which looks like:
+//
+//	P2P-SERV-DISC-REQ 2412 02:00:00:00:01:00 1 0 02000001
+type P2PServiceDiscoveryRequest struct {
+	// Frequency is the frequency, in Mhz, the request was received on.
+	Frequency int
+
+	// Peer is the address of the peer which sent the request.
+	Peer net.HardwareAddr
+
+	// DialogToken identifies the request in the response.
+	DialogToken int
+
+	// UpdateIndicator is the peer's service update indicator.
+	UpdateIndicator int
+
+	// Queries are the requested services.
+	Queries []P2PServiceTLV
+}
+
+// ParseP2PServiceDiscoveryRequest returns the P2P-SERV-DISC-REQ event
+// described by ev.  The second return value is false if ev isn't a
+// P2P-SERV-DISC-REQ event, or can't be parsed.
+func ParseP2PServiceDiscoveryRequest(ev WPAEvent) (*P2PServiceDiscoveryRequest, bool) {
+	fields := strings.Fields(ev.Line)
+	if ev.Event != "P2P-SERV-DISC-REQ" || len(fields) < 6 {
+		return nil, false
+	}
+
+	req := &P2PServiceDiscoveryRequest{}
+	var err error
+	if req.Frequency, err = strconv.Atoi(fields[1]); err != nil {
+		return nil, false
+	}
+	if req.Peer, err = net.ParseMAC(fields[2]); err != nil {
+		return nil, false
+	}
+	if req.DialogToken, err = strconv.Atoi(fields[3]); err != nil {
+		return nil, false
+	}
+	if req.UpdateIndicator, err = strconv.Atoi(fields[4]); err != nil {
+		return nil, false
+	}
+
+	b, err := hex.DecodeString(fields[5])
+	if err != nil {
+		return nil, false
+	}
+	if req.Queries, err = decodeP2PServiceTLVs(b, false); err != nil {
+		return nil, false
+	}
+	return req, true
+}
+
+// P2PServiceDiscoveryResponse is a P2P-SERV-DISC-RESP event, which looks
+// like:
+//
+//	P2P-SERV-DISC-RESP 02:00:00:00:01:00 0 0900010100...
+type P2PServiceDiscoveryResponse struct {
+	// Peer is the address of the peer which sent the response.
+	Peer net.HardwareAddr
+
+	// UpdateIndicator is the peer's service update indicator.
+	UpdateIndicator int
+
+	// Responses are the peer's answers.  Use their Bonjour() and UPnP()
+	// methods to decode them.
+	Responses []P2PServiceTLV
+}
+
+// ParseP2PServiceDiscoveryResponse returns the P2P-SERV-DISC-RESP event
+// described by ev.  The second return value is false if ev isn't a
+// P2P-SERV-DISC-RESP event, or can't be parsed.
+func ParseP2PServiceDiscoveryResponse(ev WPAEvent) (*P2PServiceDiscoveryResponse, bool) {
+	fields := strings.Fields(ev.Line)
+	if ev.Event != "P2P-SERV-DISC-RESP" || len(fields) < 4 {
+		return nil, false
+	}
+
+	resp := &P2PServiceDiscoveryResponse{}
+	var err error
+	if resp.Peer, err = net.ParseMAC(fields[1]); err != nil {
+		return nil, false
+	}
+	if resp.UpdateIndicator, err = strconv.Atoi(fields[2]); err != nil {
+		return nil, false
+	}
+
+	b, err := hex.DecodeString(fields[3])
+	if err != nil {
+		return nil, false
+	}
+	if resp.Responses, err = decodeP2PServiceTLVs(b, true); err != nil {
+		return nil, false
+	}
+	return resp, true
+}
+
+// Services returns the Bonjour and UPnP records in the response.
+func (resp *P2PServiceDiscoveryResponse) Services() (bonjour []BonjourRecord, upnp []UPnPRecord) {
+	for i := range resp.Responses {
+		if r, ok := resp.Responses[i].Bonjour(); ok {
+			bonjour = append(bonjour, *r)
+		}
+		if r, ok := resp.Responses[i].UPnP(); ok {
+			upnp = append(upnp, *r)
+		}
+	}
+	return
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"encoding/hex"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestBonjourBuilders(t *testing.T) {
+	// Examples from wpa_supplicant's README-P2P.
+	tests := []struct {
+		got    []byte
+		expect string
+	}{
+		{BonjourQuery("myprinter._ipp._tcp.local.", DNSTypeTXT), "096d797072696e746572045f697070c00c001001"},
+		{BonjourQuery("_ipp._tcp.local.", DNSTypePTR), "045f697070c00c000c01"},
+		{BonjourPTRData("MyPrinter"), "094d795072696e746572c027"},
+		{BonjourTXTData("txtvers=1"), "09747874766572733d31"},
+		{BonjourTXTData(), "00"},
+		{UPnPQuery(0x10, "ssdp:all"), "10737364703a616c6c"},
+	}
+
+	for _, test := range tests {
+		if got := hex.EncodeToString(test.got); got != test.expect {
+			t.Errorf("got %s, expected %s", got, test.expect)
+		}
+	}
+}
+
+func TestP2PServiceCommands(t *testing.T) {
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		if cmd == "P2P_SERV_DISC_REQ 00:00:00:00:00:00 02000001" {
+			return "1f77628\n"
+		}
+		return ""
+	})
+	p := NewP2P(conn)
+	query := BonjourQuery("_ipp._tcp.local.", DNSTypePTR)
+
+	if err := p.ServiceAddBonjour(query, BonjourPTRData("MyPrinter")); err != nil {
+		t.Error(err)
+	}
+	if err := p.ServiceDelBonjour(query); err != nil {
+		t.Error(err)
+	}
+	if err := p.ServiceAddUPnP(0x10, "uuid:1234::upnp:rootdevice"); err != nil {
+		t.Error(err)
+	}
+	if err := p.ServiceDelUPnP(0x10, "uuid:1234::upnp:rootdevice"); err != nil {
+		t.Error(err)
+	}
+	if err := p.ServiceFlush(); err != nil {
+		t.Error(err)
+	}
+
+	id, err := p.ServiceDiscoveryRequest(nil, EncodeP2PServiceQueries(P2PServiceTLV{
+		Protocol:      P2PServiceAll,
+		TransactionID: 1,
+	}))
+	if err != nil || id != "1f77628" {
+		t.Errorf("ServiceDiscoveryRequest: got %q, %v", id, err)
+	}
+	if err = p.CancelServiceDiscoveryRequest(id); err != nil {
+		t.Error(err)
+	}
+	err = p.ServiceDiscoveryResponse(&P2PServiceDiscoveryRequest{
+		Frequency:   2412,
+		Peer:        net.HardwareAddr{2, 0, 0, 0, 1, 0},
+		DialogToken: 5,
+	}, EncodeP2PServiceResponses(P2PServiceTLV{
+		Protocol:      P2PServiceUPnP,
+		TransactionID: 1,
+		Status:        P2PServiceQueryDataNotAvailable,
+	}))
+	if err != nil {
+		t.Error(err)
+	}
+
+	fs.expectCommands(
+		"P2P_SERVICE_ADD bonjour 045f697070c00c000c01 094d795072696e746572c027",
+		"P2P_SERVICE_DEL bonjour 045f697070c00c000c01",
+		"P2P_SERVICE_ADD upnp 10 uuid:1234::upnp:rootdevice",
+		"P2P_SERVICE_DEL upnp 10 uuid:1234::upnp:rootdevice",
+		"P2P_SERVICE_FLUSH",
+		"P2P_SERV_DISC_REQ 00:00:00:00:00:00 02000001",
+		"P2P_SERV_DISC_CANCEL_REQ 1f77628",
+		"P2P_SERV_DISC_RESP 2412 02:00:00:00:01:00 5 0300020102",
+	)
+}
+
+func TestParseP2PServiceDiscovery(t *testing.T) {
+	req, ok := ParseP2PServiceDiscoveryRequest(WPAEvent{
+		Event: "P2P-SERV-DISC-REQ",
+		Line:  "P2P-SERV-DISC-REQ 2412 02:00:00:00:01:00 5 0 02000001",
+	})
+	expectReq := &P2PServiceDiscoveryRequest{
+		Frequency:   2412,
+		Peer:        net.HardwareAddr{2, 0, 0, 0, 1, 0},
+		DialogToken: 5,
+		Queries:     []P2PServiceTLV{{Protocol: P2PServiceAll, TransactionID: 1, Data: []byte{}}},
+	}
+	if !ok || !reflect.DeepEqual(req, expectReq) {
+		t.Errorf("P2P-SERV-DISC-REQ: got %+v", req)
+	}
+
+	tlvs := EncodeP2PServiceResponses(
+		P2PServiceTLV{
+			Protocol:      P2PServiceBonjour,
+			TransactionID: 1,
+			Data:          append(BonjourQuery("_ipp._tcp.local.", DNSTypePTR), BonjourPTRData("MyPrinter")...),
+		},
+		P2PServiceTLV{
+			Protocol:      P2PServiceBonjour,
+			TransactionID: 1,
+			Data:          append(BonjourQuery("MyPrinter._ipp._tcp.local.", DNSTypeTXT), BonjourTXTData("txtvers=1", "pdl=application/postscript")...),
+		},
+		P2PServiceTLV{
+			Protocol:      P2PServiceUPnP,
+			TransactionID: 2,
+			Data:          UPnPQuery(0x10, "uuid:1234::upnp:rootdevice,uuid:1234::urn:schemas-upnp-org:device:MediaServer:1"),
+		},
+		P2PServiceTLV{
+			Protocol:      P2PServiceWSDiscovery,
+			TransactionID: 3,
+			Status:        P2PServiceProtocolNotAvailable,
+		},
+	)
+
+	resp, ok := ParseP2PServiceDiscoveryResponse(WPAEvent{
+		Event: "P2P-SERV-DISC-RESP",
+		Line:  "P2P-SERV-DISC-RESP 02:00:00:00:01:00 1 " + hex.EncodeToString(tlvs),
+	})
+	if !ok || resp.Peer.String() != "02:00:00:00:01:00" || resp.UpdateIndicator != 1 || len(resp.Responses) != 4 {
+		t.Fatalf("P2P-SERV-DISC-RESP: got %+v", resp)
+	}
+
+	bonjour, upnp := resp.Services()
+	if len(bonjour) != 2 || len(upnp) != 1 {
+		t.Fatalf("got %d Bonjour and %d UPnP records", len(bonjour), len(upnp))
+	}
+	if bonjour[0].Name != "_ipp._tcp.local." || bonjour[0].Type != DNSTypePTR || bonjour[0].Instance != "MyPrinter._ipp._tcp.local." {
+		t.Errorf("got PTR record %+v", bonjour[0])
+	}
+	if bonjour[1].Name != "MyPrinter._ipp._tcp.local." || bonjour[1].Type != DNSTypeTXT ||
+		!reflect.DeepEqual(bonjour[1].TXT, []string{"txtvers=1", "pdl=application/postscript"}) {
+		t.Errorf("got TXT record %+v", bonjour[1])
+	}
+	if upnp[0].Version != 0x10 || !reflect.DeepEqual(upnp[0].Services, []string{
+		"uuid:1234::upnp:rootdevice",
+		"uuid:1234::urn:schemas-upnp-org:device:MediaServer:1",
+	}) {
+		t.Errorf("got UPnP record %+v", upnp[0])
+	}
+
+	if _, ok = ParseP2PServiceDiscoveryResponse(WPAEvent{
+		Event: "P2P-SERV-DISC-RESP",
+		Line:  "P2P-SERV-DISC-RESP 02:00:00:00:01:00 1 0900",
+	}); ok {
+		t.Error("parsed truncated TLV")
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestP2PInterface(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wpa_supplicant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if name := P2PInterface(dir, "wlan0"); name != "wlan0" {
+		t.Errorf("got %q without p2p-dev socket", name)
+	}
+
+	if err = ioutil.WriteFile(path.Join(dir, "p2p-dev-wlan0"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if name := P2PInterface(dir, "wlan0"); name != "p2p-dev-wlan0" {
+		t.Errorf("got %q with p2p-dev socket", name)
+	}
+}
+
+func TestP2PCommands(t *testing.T) {
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch cmd {
+		case "P2P_CONNECT 02:00:00:00:01:00 pin display go_intent=0":
+			return "12345670\n"
+		case "P2P_PEER FIRST":
+			return "02:00:00:00:01:00\npri_dev_type=1-0050F204-1\n"
+		case "P2P_PEER NEXT-02:00:00:00:01:00":
+			return "02:00:00:00:02:00\npri_dev_type=1-0050F204-1\n"
+		case "P2P_PEER NEXT-02:00:00:00:02:00":
+			return "FAIL\n"
+		}
+		return ""
+	})
+	p := NewP2P(conn)
+	peer := net.HardwareAddr{2, 0, 0, 0, 1, 0}
+	zero := 0
+
+	if err := p.Find(0); err != nil {
+		t.Error(err)
+	}
+	if err := p.Find(30); err != nil {
+		t.Error(err)
+	}
+	if err := p.StopFind(); err != nil {
+		t.Error(err)
+	}
+	if peers, err := p.Peers(); err != nil || len(peers) != 2 || peers[1].String() != "02:00:00:00:02:00" {
+		t.Errorf("Peers: got %v, %v", peers, err)
+	}
+	if pin, err := p.Connect(peer, nil); err != nil || pin != "" {
+		t.Errorf("Connect: got %q, %v", pin, err)
+	}
+	if _, err := p.Connect(peer, &P2PConnectOptions{PIN: "12345670", Persistent: true, Frequency: 2437}); err != nil {
+		t.Error(err)
+	}
+	if pin, err := p.Connect(peer, &P2PConnectOptions{PIN: "pin", Display: true, GOIntent: &zero}); err != nil || pin != "12345670" {
+		t.Errorf("Connect: got %q, %v", pin, err)
+	}
+	if err := p.Cancel(); err != nil {
+		t.Error(err)
+	}
+	if err := p.GroupAdd(true, 2412); err != nil {
+		t.Error(err)
+	}
+	if err := p.GroupRestart(3); err != nil {
+		t.Error(err)
+	}
+	if err := p.Invite(peer, "p2p-wlan0-0"); err != nil {
+		t.Error(err)
+	}
+	if err := p.InvitePersistent(peer, 3); err != nil {
+		t.Error(err)
+	}
+	if err := p.GroupRemove("p2p-wlan0-0"); err != nil {
+		t.Error(err)
+	}
+
+	fs.expectCommands(
+		"P2P_FIND",
+		"P2P_FIND 30",
+		"P2P_STOP_FIND",
+		"P2P_PEER FIRST",
+		"P2P_PEER NEXT-02:00:00:00:01:00",
+		"P2P_PEER NEXT-02:00:00:00:02:00",
+		"P2P_CONNECT 02:00:00:00:01:00 pbc",
+		"P2P_CONNECT 02:00:00:00:01:00 12345670 keypad persistent freq=2437",
+		"P2P_CONNECT 02:00:00:00:01:00 pin display go_intent=0",
+		"P2P_CANCEL",
+		"P2P_GROUP_ADD persistent freq=2412",
+		"P2P_GROUP_ADD persistent=3",
+		"P2P_INVITE group=p2p-wlan0-0 peer=02:00:00:00:01:00",
+		"P2P_INVITE persistent=3 peer=02:00:00:00:01:00",
+		"P2P_GROUP_REMOVE p2p-wlan0-0",
+	)
+}
+
+func TestParseP2PPeer(t *testing.T) {
+	peer, err := parseP2PPeer("02:00:00:00:01:00\n" +
+		"pri_dev_type=10-0050F204-5\n" +
+		"device_name=Printer\n" +
+		"manufacturer=Acme\n" +
+		"model_name=P1\n" +
+		"model_number=1\n" +
+		"serial_number=123\n" +
+		"config_methods=0x188\n" +
+		"dev_capab=0x25\n" +
+		"group_capab=0x0\n" +
+		"level=-42\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if peer.Address().String() != "02:00:00:00:01:00" || peer.Name() != "Printer" ||
+		peer.DeviceType() != "10-0050F204-5" || peer.Manufacturer() != "Acme" ||
+		peer.ModelName() != "P1" || peer.ModelNumber() != "1" || peer.SerialNumber() != "123" ||
+		peer.ConfigMethods() != 0x188 || peer.DeviceCapability() != 0x25 ||
+		peer.GroupCapability() != 0 || peer.Level() != -42 {
+		t.Errorf("got %+v", peer)
+	}
+
+	if _, err = parseP2PPeer("FAIL\n"); err == nil {
+		t.Error("expected error parsing FAIL")
+	}
+}
+
+func TestParseP2PEvents(t *testing.T) {
+	found, ok := ParseP2PDeviceFound(WPAEvent{
+		Event: "P2P-DEVICE-FOUND",
+		Line:  "P2P-DEVICE-FOUND 02:00:00:00:01:00 p2p_dev_addr=02:00:00:00:01:00 pri_dev_type=1-0050F204-1 name='Wireless Client' config_methods=0x188 dev_capab=0x25 group_capab=0x0 new=1",
+	})
+	if !ok || found.Address.String() != "02:00:00:00:01:00" || found.Name != "Wireless Client" ||
+		found.DeviceType != "1-0050F204-1" || found.ConfigMethods != 0x188 || found.DeviceCapability != 0x25 {
+		t.Errorf("P2P-DEVICE-FOUND: got %+v", found)
+	}
+
+	req, ok := ParseP2PGoNegRequest(WPAEvent{
+		Event: "P2P-GO-NEG-REQUEST",
+		Line:  "P2P-GO-NEG-REQUEST 02:00:00:00:01:00 dev_passwd_id=4 go_intent=7",
+	})
+	if !ok || req.Peer.String() != "02:00:00:00:01:00" || req.DevicePasswordID != 4 || req.GOIntent != 7 {
+		t.Errorf("P2P-GO-NEG-REQUEST: got %+v", req)
+	}
+
+	started, ok := ParseP2PGroupStarted(WPAEvent{
+		Event: "P2P-GROUP-STARTED",
+		Line:  `P2P-GROUP-STARTED p2p-wlan0-0 GO ssid="DIRECT-ab \"x\"" freq=2412 passphrase="12345678" go_dev_addr=02:00:00:00:00:01 [PERSISTENT]`,
+	})
+	expect := P2PGroupStarted{
+		Interface:       "p2p-wlan0-0",
+		GO:              true,
+		SSID:            `DIRECT-ab "x"`,
+		Frequency:       2412,
+		Passphrase:      "12345678",
+		GODeviceAddress: net.HardwareAddr{2, 0, 0, 0, 0, 1},
+		Persistent:      true,
+	}
+	if !ok || started.Interface != expect.Interface || started.GO != expect.GO || started.SSID != expect.SSID ||
+		started.Frequency != expect.Frequency || started.Passphrase != expect.Passphrase ||
+		started.GODeviceAddress.String() != expect.GODeviceAddress.String() || !started.Persistent {
+		t.Errorf("P2P-GROUP-STARTED: got %+v", started)
+	}
+
+	removed, ok := ParseP2PGroupRemoved(WPAEvent{
+		Event: "P2P-GROUP-REMOVED",
+		Line:  "P2P-GROUP-REMOVED p2p-wlan0-0 client reason=IDLE",
+	})
+	if !ok || removed.Interface != "p2p-wlan0-0" || removed.GO || removed.Reason != "IDLE" {
+		t.Errorf("P2P-GROUP-REMOVED: got %+v", removed)
+	}
+
+	if _, ok = ParseP2PGroupStarted(WPAEvent{Event: "P2P-GROUP-REMOVED"}); ok {
+		t.Error("parsed P2P-GROUP-REMOVED as P2P-GROUP-STARTED")
+	}
+}
+
+func TestP2PEventQueue(t *testing.T) {
+	fs, conn := newFakeServer(t, nil)
+
+	fs.event("P2P-GO-NEG-REQUEST 02:00:00:00:01:00 dev_passwd_id=4 go_intent=7")
+	ev := <-conn.EventQueue()
+	if ev.Event != "P2P-GO-NEG-REQUEST" || ev.Arguments["go_intent"] != "7" {
+		t.Errorf("got %+v", ev)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrPassphraseLength is returned when a WPA passphrase is not
+	// between 8 and 63 characters.
+	ErrPassphraseLength = errors.New("passphrase must be 8 to 63 characters")
+
+	// ErrPassphraseCharset is returned when a WPA passphrase contains
+	// characters other than printable ASCII.
+	ErrPassphraseCharset = errors.New("passphrase must only contain printable ASCII characters")
+
+	// ErrEmptySSID is returned when deriving a PSK without an SSID.
+	ErrEmptySSID = errors.New("SSID must not be empty")
+
+	// ErrEmptySAEPassword is returned when a SAE password is empty.
+	ErrEmptySAEPassword = errors.New("SAE password must not be empty")
+)
+
+// SAEPWE is the mechanism used to derive the password element during SAE
+// authentication.
+type SAEPWE int
+
+const (
+	// SAEPWEHuntAndPeck uses the original hunting-and-pecking loop.
+	SAEPWEHuntAndPeck SAEPWE = iota
+
+	// SAEPWEHashToElement uses hash-to-element only.
+	SAEPWEHashToElement
+
+	// SAEPWEBoth allows either mechanism.
+	SAEPWEBoth
+)
+
+// ValidatePassphrase checks that passphrase is acceptable to wpa_supplicant
+// as a WPA-PSK passphrase.
+func ValidatePassphrase(passphrase string) error {
+	if len(passphrase) < 8 || len(passphrase) > 63 {
+		return ErrPassphraseLength
+	}
+	if !isPrintable(passphrase) {
+		return ErrPassphraseCharset
+	}
+	return nil
+}
+
+// ValidateSAEPassword checks that password is acceptable to wpa_supplicant
+// as a SAE password.  Unlike WPA-PSK passphrases, SAE passwords have no
+// length or character set restrictions.
+func ValidateSAEPassword(password string) error {
+	if password == "" {
+		return ErrEmptySAEPassword
+	}
+	return nil
+}
+
+// DerivePSK computes the 256-bit WPA pre-shared key for a passphrase and
+// SSID, as PBKDF2-SHA1(passphrase, SSID, 4096).  This is the same key
+// wpa_supplicant derives from a quoted passphrase, so the passphrase itself
+// never needs to be sent to it.
+func DerivePSK(passphrase, ssid string) ([]byte, error) {
+	if err := ValidatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if ssid == "" {
+		return nil, ErrEmptySSID
+	}
+	if len(ssid) > 32 {
+		return nil, fmt.Errorf("SSID must be at most 32 bytes, got %d", len(ssid))
+	}
+
+	return pbkdf2SHA1([]byte(passphrase), []byte(ssid), 4096, 32), nil
+}
+
+// pbkdf2SHA1 is PBKDF2 (RFC 8018) using HMAC-SHA1, as used by IEEE 802.11i
+// to derive a PSK from a passphrase.
+func pbkdf2SHA1(password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(sha1.New, password)
+	key := make([]byte, 0, keyLen+sha1.Size)
+
+	var block [4]byte
+	u := make([]byte, sha1.Size)
+	t := make([]byte, sha1.Size)
+	for i := uint32(1); len(key) < keyLen; i++ {
+		binary.BigEndian.PutUint32(block[:], i)
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(block[:])
+		u = prf.Sum(u[:0])
+		copy(t, u)
+
+		for n := 1; n < iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
+
+// SetPassphrase derives the PSK from passphrase and the network's SSID, and
+// stores it in cfg.PSK as 64 hex digits.  The SSID must be set first.
+func (cfg *NetworkConfig) SetPassphrase(passphrase string) error {
+	psk, err := DerivePSK(passphrase, cfg.SSID)
+	if err != nil {
+		return err
+	}
+
+	cfg.PSK = hex.EncodeToString(psk)
+	return nil
+}
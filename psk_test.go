@@ -0,0 +1,96 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// Test vectors from IEEE 802.11i-2004, Annex H.4.
+var derivePSKTests = []struct {
+	passphrase, ssid, expect string
+}{
+	{"password", "IEEE", "f42c6fc52df0ebef9ebb4b90b38a5f902e83fe1b135a70e23aed762e9710a12e"},
+	{"ThisIsAPassword", "ThisIsASSID", "0dc0d6eb90555ed6419756b9a15ec3e3209b63df707dd508d14581f8982721af"},
+}
+
+func TestDerivePSK(t *testing.T) {
+	for _, test := range derivePSKTests {
+		psk, err := DerivePSK(test.passphrase, test.ssid)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got := hex.EncodeToString(psk); got != test.expect {
+			t.Errorf("wrong PSK for %q/%q (got %s, expect %s)", test.passphrase, test.ssid, got, test.expect)
+		}
+	}
+}
+
+func TestValidatePassphrase(t *testing.T) {
+	tests := []struct {
+		passphrase string
+		expect     error
+	}{
+		{"password", nil},
+		{"short", ErrPassphraseLength},
+		{strings.Repeat("x", 63), nil},
+		{strings.Repeat("x", 64), ErrPassphraseLength},
+		{"pass\tword", ErrPassphraseCharset},
+		{"pässword", ErrPassphraseCharset},
+	}
+
+	for _, test := range tests {
+		if err := ValidatePassphrase(test.passphrase); err != test.expect {
+			t.Errorf("wrong result for %q (got %v, expect %v)", test.passphrase, err, test.expect)
+		}
+	}
+}
+
+func TestSetPassphrase(t *testing.T) {
+	cfg := NetworkConfig{SSID: "IEEE"}
+	if err := cfg.SetPassphrase("password"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PSK != derivePSKTests[0].expect {
+		t.Errorf("wrong PSK (got %s, expect %s)", cfg.PSK, derivePSKTests[0].expect)
+	}
+
+	// The derived key must be sent unquoted, so wpa_supplicant doesn't
+	// mistake it for a passphrase.
+	if got := encodeValue("psk", cfg.PSK); got != cfg.PSK {
+		t.Errorf("PSK was encoded as %s", got)
+	}
+
+	if err := (&NetworkConfig{}).SetPassphrase("password"); err != ErrEmptySSID {
+		t.Errorf("expected ErrEmptySSID, got %v", err)
+	}
+}
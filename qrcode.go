@@ -0,0 +1,327 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// URIError is returned when a WIFI: or DPP: URI can't be parsed.
+type URIError struct {
+	// URI is the URI which couldn't be parsed.
+	URI string
+
+	// Reason describes the problem.
+	Reason string
+}
+
+func (err *URIError) Error() string {
+	return fmt.Sprintf("invalid URI %q: %s", err.URI, err.Reason)
+}
+
+// WiFiURI is a WIFI: URI, as encoded in QR codes for joining a network.  It
+// looks like:
+//
+//	WIFI:T:WPA;S:MyNet;P:secret;H:true;;
+type WiFiURI struct {
+	// Type is the authentication type: "WPA" (which includes WPA2 and
+	// WPA3-Personal), "SAE", "WEP", or "nopass".  It may be empty for an
+	// open network.
+	Type string
+
+	// SSID is the raw SSID of the network.
+	SSID string
+
+	// Password is the network's passphrase or key.
+	Password string
+
+	// Hidden is true if the network doesn't broadcast its SSID.
+	Hidden bool
+
+	// SAEPasswordID identifies Password to the access point.
+	SAEPasswordID string
+
+	// TransitionDisable is the WPA3 transition disable bitmap.  Bit 0
+	// means the network only accepts SAE.
+	TransitionDisable int
+
+	// PublicKey is the base64-encoded public key of the network, if any.
+	PublicKey string
+}
+
+// wifiURIEscaper escapes the characters which are special in WIFI: URI
+// fields.
+var wifiURIEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	`:`, `\:`,
+	`"`, `\"`,
+)
+
+// ParseWiFiURI parses a WIFI: URI.  Fields may be in any order, and
+// unknown fields are ignored.
+func ParseWiFiURI(uri string) (*WiFiURI, error) {
+	if len(uri) < 5 || !strings.EqualFold(uri[:5], "WIFI:") {
+		return nil, &URIError{uri, "not a WIFI: URI"}
+	}
+
+	fields, err := splitWiFiURI(uri[5:])
+	if err != nil {
+		return nil, &URIError{uri, err.Error()}
+	}
+
+	u := &WiFiURI{}
+	for _, f := range fields {
+		kv := strings.SplitN(f, ":", 2)
+		if len(kv) != 2 {
+			return nil, &URIError{uri, fmt.Sprintf("field %q has no value", f)}
+		}
+
+		value := unescapeWiFiURI(kv[1])
+		switch strings.ToUpper(kv[0]) {
+		case "T":
+			u.Type = value
+		case "S":
+			u.SSID = value
+		case "P":
+			u.Password = value
+		case "H":
+			u.Hidden = strings.EqualFold(value, "true")
+		case "I":
+			u.SAEPasswordID = value
+		case "R":
+			n, err := strconv.ParseUint(value, 16, 8)
+			if err != nil {
+				return nil, &URIError{uri, "invalid transition disable value " + value}
+			}
+			u.TransitionDisable = int(n)
+		case "K":
+			u.PublicKey = value
+		}
+	}
+
+	if u.SSID == "" {
+		return nil, &URIError{uri, "no SSID"}
+	}
+	return u, nil
+}
+
+// splitWiFiURI splits the fields of a WIFI: URI at unescaped semicolons,
+// stopping at the empty field which terminates the URI.  Escapes are kept,
+// so fields can be split at their first colon.
+func splitWiFiURI(s string) ([]string, error) {
+	var fields []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ';':
+			if i == start {
+				return fields, nil
+			}
+			fields = append(fields, s[start:i])
+			start = i + 1
+		}
+	}
+	return fields, fmt.Errorf("missing terminating ;;")
+}
+
+// unescapeWiFiURI removes the backslash escapes from a WIFI: URI field,
+// and the double quotes some generators put around the SSID and password.
+func unescapeWiFiURI(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' && s[len(s)-2] != '\\' {
+		s = s[1 : len(s)-1]
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// String returns the WIFI: URI, escaping special characters.
+func (u *WiFiURI) String() string {
+	var b strings.Builder
+	b.WriteString("WIFI:")
+	if u.Type != "" {
+		b.WriteString("T:" + u.Type + ";")
+	}
+	if u.TransitionDisable != 0 {
+		fmt.Fprintf(&b, "R:%X;", u.TransitionDisable)
+	}
+	b.WriteString("S:" + wifiURIEscaper.Replace(u.SSID) + ";")
+	if u.SAEPasswordID != "" {
+		b.WriteString("I:" + wifiURIEscaper.Replace(u.SAEPasswordID) + ";")
+	}
+	if u.Password != "" {
+		b.WriteString("P:" + wifiURIEscaper.Replace(u.Password) + ";")
+	}
+	if u.Hidden {
+		b.WriteString("H:true;")
+	}
+	if u.PublicKey != "" {
+		b.WriteString("K:" + wifiURIEscaper.Replace(u.PublicKey) + ";")
+	}
+	b.WriteString(";")
+	return b.String()
+}
+
+// NetworkConfig returns the configuration of the network described by the
+// URI, ready to pass to ApplyNetwork() or Connect().
+func (u *WiFiURI) NetworkConfig() NetworkConfig {
+	cfg := NetworkConfig{
+		SSID:     u.SSID,
+		ScanSSID: u.Hidden,
+	}
+
+	switch strings.ToUpper(u.Type) {
+	case "WPA", "":
+		if u.Password == "" {
+			cfg.KeyMgmt = "NONE"
+			break
+		}
+		if u.TransitionDisable&1 != 0 {
+			cfg.KeyMgmt = "SAE"
+			cfg.SAEPassword = u.Password
+			cfg.SAEPasswordID = u.SAEPasswordID
+			cfg.IEEE80211w = 2
+			break
+		}
+		cfg.KeyMgmt = "WPA-PSK"
+		cfg.PSK = u.Password
+	case "SAE":
+		cfg.KeyMgmt = "SAE"
+		cfg.SAEPassword = u.Password
+		cfg.SAEPasswordID = u.SAEPasswordID
+		cfg.IEEE80211w = 2
+	case "WEP":
+		cfg.KeyMgmt = "NONE"
+		cfg.WEPKey0 = u.Password
+	default:
+		cfg.KeyMgmt = "NONE"
+	}
+	return cfg
+}
+
+// DPPURI is a DPP: bootstrapping URI, as encoded in the QR codes used by
+// Wi-Fi Easy Connect.  It looks like:
+//
+//	DPP:C:81/1,115/36;M:020000000100;I:SN=4774LH2b4044;V:2;K:MDkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDIgADURzxmttZoIRIPWGoQMV00XHWCAQIhXruVWOz0NjlkIA=;;
+type DPPURI struct {
+	// Channels are the operating class/channel pairs on which the device
+	// listens, e.g. "81/1".
+	Channels []string
+
+	// MAC is the device's MAC address.
+	MAC net.HardwareAddr
+
+	// Info is free-form information about the device.
+	Info string
+
+	// Version is the DPP protocol version supported by the device, or
+	// 0 for version 1.
+	Version int
+
+	// Key is the base64-encoded DER public key of the device.
+	Key string
+}
+
+// ParseDPPURI parses a DPP: URI.  Unknown fields are ignored.
+func ParseDPPURI(uri string) (*DPPURI, error) {
+	if !strings.HasPrefix(uri, "DPP:") {
+		return nil, &URIError{uri, "not a DPP: URI"}
+	}
+	if !strings.HasSuffix(uri, ";;") {
+		return nil, &URIError{uri, "missing terminating ;;"}
+	}
+
+	u := &DPPURI{}
+	for _, f := range strings.Split(strings.TrimSuffix(uri[4:], ";;"), ";") {
+		kv := strings.SplitN(f, ":", 2)
+		if len(kv) != 2 {
+			return nil, &URIError{uri, fmt.Sprintf("field %q has no value", f)}
+		}
+
+		switch kv[0] {
+		case "C":
+			u.Channels = strings.Split(kv[1], ",")
+		case "M":
+			b, err := hex.DecodeString(kv[1])
+			if err != nil || len(b) != 6 {
+				return nil, &URIError{uri, "invalid MAC address " + kv[1]}
+			}
+			u.MAC = net.HardwareAddr(b)
+		case "I":
+			u.Info = kv[1]
+		case "V":
+			n, err := strconv.Atoi(kv[1])
+			if err != nil {
+				return nil, &URIError{uri, "invalid version " + kv[1]}
+			}
+			u.Version = n
+		case "K":
+			u.Key = kv[1]
+		}
+	}
+
+	if u.Key == "" {
+		return nil, &URIError{uri, "no public key"}
+	}
+	return u, nil
+}
+
+// String returns the DPP: URI.
+func (u *DPPURI) String() string {
+	var b strings.Builder
+	b.WriteString("DPP:")
+	if len(u.Channels) > 0 {
+		b.WriteString("C:" + strings.Join(u.Channels, ",") + ";")
+	}
+	if u.MAC != nil {
+		b.WriteString("M:" + hex.EncodeToString(u.MAC) + ";")
+	}
+	if u.Info != "" {
+		b.WriteString("I:" + u.Info + ";")
+	}
+	if u.Version != 0 {
+		fmt.Fprintf(&b, "V:%d;", u.Version)
+	}
+	b.WriteString("K:" + u.Key + ";;")
+	return b.String()
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestParseWiFiURI(t *testing.T) {
+	tests := []struct {
+		uri    string
+		expect WiFiURI
+		cfg    NetworkConfig
+	}{
+		{
+			uri:    "WIFI:T:WPA;S:MyNet;P:secret123;H:true;;",
+			expect: WiFiURI{Type: "WPA", SSID: "MyNet", Password: "secret123", Hidden: true},
+			cfg:    NetworkConfig{SSID: "MyNet", ScanSSID: true, KeyMgmt: "WPA-PSK", PSK: "secret123"},
+		}, {
+			uri:    `WIFI:S:a\;b\,c\:d\\e;T:WPA;P:"p\"w\;d";;`,
+			expect: WiFiURI{Type: "WPA", SSID: `a;b,c:d\e`, Password: `p"w;d`},
+			cfg:    NetworkConfig{SSID: `a;b,c:d\e`, KeyMgmt: "WPA-PSK", PSK: `p"w;d`},
+		}, {
+			uri:    "WIFI:T:WPA;R:1;S:MyNet;I:user;P:secret;K:MDkw;;",
+			expect: WiFiURI{Type: "WPA", SSID: "MyNet", Password: "secret", SAEPasswordID: "user", TransitionDisable: 1, PublicKey: "MDkw"},
+			cfg:    NetworkConfig{SSID: "MyNet", KeyMgmt: "SAE", SAEPassword: "secret", SAEPasswordID: "user", IEEE80211w: 2},
+		}, {
+			uri:    "WIFI:T:WEP;S:Old;P:0102030405;;",
+			expect: WiFiURI{Type: "WEP", SSID: "Old", Password: "0102030405"},
+			cfg:    NetworkConfig{SSID: "Old", KeyMgmt: "NONE", WEPKey0: "0102030405"},
+		}, {
+			uri:    "wifi:T:nopass;S:Cafe;;",
+			expect: WiFiURI{Type: "nopass", SSID: "Cafe"},
+			cfg:    NetworkConfig{SSID: "Cafe", KeyMgmt: "NONE"},
+		},
+	}
+
+	for _, test := range tests {
+		u, err := ParseWiFiURI(test.uri)
+		if err != nil {
+			t.Errorf("%s: %v", test.uri, err)
+			continue
+		}
+		if *u != test.expect {
+			t.Errorf("%s: got %+v", test.uri, u)
+		}
+		if cfg := u.NetworkConfig(); cfg != test.cfg {
+			t.Errorf("%s: got config %+v", test.uri, cfg)
+		}
+	}
+
+	for _, uri := range []string{
+		"",
+		"DPP:K:MDkw;;",
+		"WIFI:T:WPA;S:MyNet;",
+		"WIFI:T:WPA;P:secret;;",
+		"WIFI:S:MyNet;H;;",
+		"WIFI:S:MyNet;R:zz;;",
+	} {
+		if _, err := ParseWiFiURI(uri); err == nil {
+			t.Errorf("%q: expected error", uri)
+		}
+	}
+}
+
+func TestWiFiURIString(t *testing.T) {
+	u := &WiFiURI{Type: "WPA", SSID: "MyNet", Password: "secret", Hidden: true}
+	if s := u.String(); s != "WIFI:T:WPA;S:MyNet;P:secret;H:true;;" {
+		t.Errorf("got %s", s)
+	}
+
+	u = &WiFiURI{Type: "SAE", SSID: `a;b,c:d\e"`, Password: "p:w", TransitionDisable: 1}
+	s := u.String()
+	if s != `WIFI:T:SAE;R:1;S:a\;b\,c\:d\\e\";P:p\:w;;` {
+		t.Errorf("got %s", s)
+	}
+	if parsed, err := ParseWiFiURI(s); err != nil || *parsed != *u {
+		t.Errorf("round trip: got %+v, %v", parsed, err)
+	}
+}
+
+func TestParseDPPURI(t *testing.T) {
+	uri := "DPP:C:81/1,115/36;M:020000000100;I:SN=4774LH2b4044;V:2;K:MDkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDIgADURzxmttZoIRIPWGoQMV00XHWCAQIhXruVWOz0NjlkIA=;;"
+	u, err := ParseDPPURI(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := &DPPURI{
+		Channels: []string{"81/1", "115/36"},
+		MAC:      net.HardwareAddr{2, 0, 0, 0, 1, 0},
+		Info:     "SN=4774LH2b4044",
+		Version:  2,
+		Key:      "MDkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDIgADURzxmttZoIRIPWGoQMV00XHWCAQIhXruVWOz0NjlkIA=",
+	}
+	if !reflect.DeepEqual(u, expect) {
+		t.Errorf("got %+v", u)
+	}
+	if s := u.String(); s != uri {
+		t.Errorf("String: got %s", s)
+	}
+
+	if s := (&DPPURI{Key: "MDkw"}).String(); s != "DPP:K:MDkw;;" {
+		t.Errorf("String: got %s", s)
+	}
+
+	for _, uri := range []string{
+		"WIFI:S:MyNet;;",
+		"DPP:K:MDkw;",
+		"DPP:C:81/1;;",
+		"DPP:M:0200;K:MDkw;;",
+		"DPP:V:x;K:MDkw;;",
+		"DPP:X;K:MDkw;;",
+	} {
+		if _, err := ParseDPPURI(uri); err == nil {
+			t.Errorf("%q: expected error", uri)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// ReconcileOptions modifies the behavior of Reconcile().
+type ReconcileOptions struct {
+	// SaveConfig writes the configuration to disk if anything changed.
+	SaveConfig bool
+}
+
+// ReconcileResult lists the networks changed by Reconcile().
+type ReconcileResult struct {
+	Added, Updated, Removed []int
+}
+
+// Changed returns true if Reconcile() changed any network.
+func (r *ReconcileResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Removed) > 0
+}
+
+// Reconcile makes the configured networks match desired, making as few
+// changes as possible.  Networks are matched by IDStr if it is set, and by
+// SSID otherwise.
+//
+// Configured networks which don't match any desired network are removed,
+// except for persistent P2P groups (Disabled of 2), which are left alone.
+// Desired networks which don't match any configured network are added and
+// enabled.  For networks which match, only the variables which desired sets
+// (i.e. which are non-zero) are compared and updated, and disabled networks
+// are enabled unless desired disables them.
+//
+// Secrets such as the PSK cannot be read back from wpa_supplicant, so they
+// are rewritten on matched networks without counting as a change.  Since
+// setting them can disconnect, the currently connected network is only
+// touched if one of its other variables changed.  A change to only a secret
+// is therefore not applied to the connected network, and is only saved if
+// something else changed.
+func Reconcile(ctx context.Context, c Conn, desired []NetworkConfig, opts *ReconcileOptions) (*ReconcileResult, error) {
+	if opts == nil {
+		opts = &ReconcileOptions{}
+	}
+
+	for i := range desired {
+		if err := desired[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	networks, err := c.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]int)
+	configs := make(map[int]NetworkConfig)
+	current := -1
+	res := &ReconcileResult{}
+	var unmatched []int
+	for _, n := range networks {
+		if err = ctx.Err(); err != nil {
+			return res, err
+		}
+
+		id := n.NetworkID()
+		cfg, err := c.ReadNetwork(id)
+		if err != nil {
+			return res, err
+		}
+		if cfg.Disabled == 2 {
+			continue
+		}
+		if n.Current() {
+			current = id
+		}
+
+		// If there are duplicates, keep the first and remove the
+		// rest.
+		key := reconcileKey(&cfg)
+		if _, ok := existing[key]; ok {
+			unmatched = append(unmatched, id)
+			continue
+		}
+		existing[key] = id
+		configs[id] = cfg
+	}
+
+	matched := make(map[int]bool)
+	for i := range desired {
+		if err = ctx.Err(); err != nil {
+			return res, err
+		}
+
+		want := &desired[i]
+		id, ok := existing[reconcileKey(want)]
+		if !ok || matched[id] {
+			if id, err = c.AddNetwork(); err != nil {
+				return res, err
+			}
+			res.Added = append(res.Added, id)
+
+			if err = c.ApplyNetwork(id, *want); err != nil {
+				return res, err
+			}
+			if want.Disabled == 0 {
+				if err = c.EnableNetwork(id); err != nil {
+					return res, err
+				}
+			}
+			continue
+		}
+		matched[id] = true
+
+		have := configs[id]
+		var changed, secrets []networkField
+		for _, f := range networkFields {
+			value, ok := f.get(want)
+			if !ok {
+				continue
+			}
+
+			// Since we can't tell whether secrets changed, they
+			// are rewritten along with any other changes.
+			if f.secret {
+				secrets = append(secrets, f)
+			} else if v, _ := f.get(&have); !sameValue(f, value, v) {
+				changed = append(changed, f)
+			}
+		}
+		if id != current || len(changed) > 0 {
+			for _, f := range append(changed, secrets...) {
+				value, _ := f.get(want)
+				if err = c.SetNetwork(id, f.name, value); err != nil {
+					return res, err
+				}
+			}
+		}
+
+		// Enable disabled networks, the same as added ones.
+		enable := want.Disabled == 0 && have.Disabled == 1
+		if enable {
+			if err = c.EnableNetwork(id); err != nil {
+				return res, err
+			}
+		}
+
+		if len(changed) > 0 || enable {
+			res.Updated = append(res.Updated, id)
+		}
+	}
+
+	for _, id := range existing {
+		if !matched[id] {
+			unmatched = append(unmatched, id)
+		}
+	}
+	sort.Ints(unmatched)
+	for _, id := range unmatched {
+		if err = c.RemoveNetwork(id); err != nil {
+			return res, err
+		}
+		res.Removed = append(res.Removed, id)
+	}
+
+	if opts.SaveConfig && res.Changed() {
+		if err = c.SaveConfig(); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// reconcileKey returns the key used to match a desired network with a
+// configured one.
+func reconcileKey(cfg *NetworkConfig) string {
+	if cfg.IDStr != "" {
+		return "id_str:" + cfg.IDStr
+	}
+	return "ssid:" + cfg.SSID
+}
+
+// sameValue returns true if two values of a network variable are
+// equivalent.  Lists of tokens are compared without regard to order or case,
+// since wpa_supplicant doesn't preserve either.
+func sameValue(f networkField, a, b string) bool {
+	if f.kind != kindRaw {
+		return a == b
+	}
+
+	normalize := func(s string) string {
+		tokens := strings.Fields(strings.ToUpper(s))
+		sort.Strings(tokens)
+		return strings.Join(tokens, " ")
+	}
+	return normalize(a) == normalize(b)
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// networkStore answers LIST_NETWORKS and GET_NETWORK from a fixed set of
+// networks, in a fakeServer handler.
+func networkStore(networks map[int]map[string]string, current int) func(string) string {
+	return func(cmd string) string {
+		fields := strings.Fields(cmd)
+		switch {
+		case cmd == "LIST_NETWORKS":
+			var ids []int
+			for id := range networks {
+				ids = append(ids, id)
+			}
+			sort.Ints(ids)
+
+			resp := "network id / ssid / bssid / flags\n"
+			for _, id := range ids {
+				var flags string
+				if id == current {
+					flags = "[CURRENT]"
+				}
+				resp += fmt.Sprintf("%d\t%s\tany\t%s\n", id, strings.Trim(networks[id]["ssid"], `"`), flags)
+			}
+			return resp
+
+		case len(fields) == 3 && fields[0] == "GET_NETWORK":
+			var id int
+			fmt.Sscan(fields[1], &id)
+			if v, ok := networks[id][fields[2]]; ok {
+				return v
+			}
+			return "FAIL\n"
+
+		case cmd == "ADD_NETWORK":
+			return "9\n"
+		}
+		return ""
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	fs, conn := newFakeServer(t, networkStore(map[int]map[string]string{
+		0: {"ssid": `"home"`, "key_mgmt": "WPA-PSK", "psk": "*", "priority": "0", "proto": "WPA RSN"},
+		1: {"ssid": `"office"`, "key_mgmt": "WPA-PSK", "psk": "*", "priority": "1"},
+		2: {"ssid": `"old"`, "key_mgmt": "NONE", "priority": "0"},
+		3: {"ssid": `"home"`, "key_mgmt": "WPA-PSK", "psk": "*", "priority": "0"},
+	}, 0))
+
+	res, err := Reconcile(context.Background(), conn, []NetworkConfig{
+		// unchanged (and connected)
+		{SSID: "home", KeyMgmt: "WPA-PSK", Proto: "RSN WPA", PSK: "password"},
+		// changed priority
+		{SSID: "office", KeyMgmt: "WPA-PSK", PSK: "password", Priority: 5},
+		// new
+		{SSID: "guest", KeyMgmt: "NONE"},
+	}, &ReconcileOptions{SaveConfig: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(res.Added) != "[9]" || fmt.Sprint(res.Updated) != "[1]" || fmt.Sprint(res.Removed) != "[2 3]" {
+		t.Errorf("wrong result (got %+v)", res)
+	}
+
+	var changes []string
+	for _, cmd := range fs.commands() {
+		if !strings.HasPrefix(cmd, "GET_NETWORK ") {
+			changes = append(changes, cmd)
+		}
+	}
+	expect := []string{
+		"LIST_NETWORKS",
+		"SET_NETWORK 1 priority 5",
+		`SET_NETWORK 1 psk "password"`,
+		"ADD_NETWORK",
+		`SET_NETWORK 9 ssid "guest"`,
+		"SET_NETWORK 9 key_mgmt NONE",
+		"ENABLE_NETWORK 9",
+		"REMOVE_NETWORK 2",
+		"REMOVE_NETWORK 3",
+		"SAVE_CONFIG",
+	}
+	if fmt.Sprint(changes) != fmt.Sprint(expect) {
+		t.Errorf("got commands %q, expected %q", changes, expect)
+	}
+}
+
+func TestReconcileUnchanged(t *testing.T) {
+	fs, conn := newFakeServer(t, networkStore(map[int]map[string]string{
+		0: {"ssid": `"home"`, "key_mgmt": "NONE", "id_str": `"home"`},
+	}, 0))
+
+	res, err := Reconcile(context.Background(), conn, []NetworkConfig{
+		{SSID: "home", IDStr: "home", KeyMgmt: "NONE"},
+	}, &ReconcileOptions{SaveConfig: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changed() {
+		t.Errorf("unexpected changes: %+v", res)
+	}
+
+	for _, cmd := range fs.commands() {
+		if cmd != "LIST_NETWORKS" && !strings.HasPrefix(cmd, "GET_NETWORK ") {
+			t.Errorf("unexpected command %q", cmd)
+		}
+	}
+}
+
+// reconcileChanges runs Reconcile() against a single configured network,
+// which is connected if current is true, and returns the commands it sent,
+// other than LIST_NETWORKS and GET_NETWORK.
+func reconcileChanges(t *testing.T, network map[string]string, current bool, want []NetworkConfig) (*ReconcileResult, []string) {
+	currentID := -1
+	if current {
+		currentID = 0
+	}
+	fs, conn := newFakeServer(t, networkStore(map[int]map[string]string{0: network}, currentID))
+
+	res, err := Reconcile(context.Background(), conn, want, &ReconcileOptions{SaveConfig: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var changes []string
+	for _, cmd := range fs.commands() {
+		if cmd != "LIST_NETWORKS" && !strings.HasPrefix(cmd, "GET_NETWORK ") {
+			changes = append(changes, cmd)
+		}
+	}
+	return res, changes
+}
+
+func TestReconcileSecretOnly(t *testing.T) {
+	network := map[string]string{"ssid": `"home"`, "key_mgmt": "WPA-PSK", "psk": "*"}
+	want := []NetworkConfig{{SSID: "home", KeyMgmt: "WPA-PSK", PSK: "rotated password"}}
+
+	// Secrets are rewritten, but don't count as a change.
+	res, changes := reconcileChanges(t, network, false, want)
+	if res.Changed() {
+		t.Errorf("unexpected changes: %+v", res)
+	}
+	expect := []string{`SET_NETWORK 0 psk "rotated password"`}
+	if fmt.Sprint(changes) != fmt.Sprint(expect) {
+		t.Errorf("got commands %q, expected %q", changes, expect)
+	}
+
+	// The connected network is left alone.
+	res, changes = reconcileChanges(t, network, true, want)
+	if res.Changed() || len(changes) != 0 {
+		t.Errorf("unexpected changes: %+v, %q", res, changes)
+	}
+
+	// Unless something else changed.
+	want[0].Priority = 2
+	res, changes = reconcileChanges(t, network, true, want)
+	if fmt.Sprint(res.Updated) != "[0]" {
+		t.Errorf("wrong result (got %+v)", res)
+	}
+	expect = []string{"SET_NETWORK 0 priority 2", `SET_NETWORK 0 psk "rotated password"`, "SAVE_CONFIG"}
+	if fmt.Sprint(changes) != fmt.Sprint(expect) {
+		t.Errorf("got commands %q, expected %q", changes, expect)
+	}
+}
+
+func TestReconcileDisabledOnly(t *testing.T) {
+	res, changes := reconcileChanges(t,
+		map[string]string{"ssid": `"home"`, "key_mgmt": "NONE", "disabled": "1"}, false,
+		[]NetworkConfig{{SSID: "home", KeyMgmt: "NONE"}})
+
+	if fmt.Sprint(res.Updated) != "[0]" {
+		t.Errorf("wrong result (got %+v)", res)
+	}
+	expect := []string{"ENABLE_NETWORK 0", "SAVE_CONFIG"}
+	if fmt.Sprint(changes) != fmt.Sprint(expect) {
+		t.Errorf("got commands %q, expected %q", changes, expect)
+	}
+
+	// A network which should stay disabled is left alone.
+	res, changes = reconcileChanges(t,
+		map[string]string{"ssid": `"home"`, "key_mgmt": "NONE", "disabled": "1"}, false,
+		[]NetworkConfig{{SSID: "home", KeyMgmt: "NONE", Disabled: 1}})
+	if res.Changed() || len(changes) != 0 {
+		t.Errorf("unexpected changes: %+v, %q", res, changes)
+	}
+}
+
+func TestReconcileP2PPersistent(t *testing.T) {
+	// Persistent P2P groups aren't removed, even though they aren't
+	// desired.
+	res, changes := reconcileChanges(t,
+		map[string]string{"ssid": `"DIRECT-ab"`, "key_mgmt": "WPA-PSK", "disabled": "2"}, false,
+		nil)
+	if res.Changed() || len(changes) != 0 {
+		t.Errorf("unexpected changes: %+v, %q", res, changes)
+	}
+}
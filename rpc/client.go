@@ -0,0 +1,420 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"pifke.org/wpasupplicant"
+	"pifke.org/wpasupplicant/rpc/wpapb"
+)
+
+// client is the implementation of wpasupplicant.Conn for a remote
+// Supplicant service.
+type client struct {
+	c      wpapb.SupplicantClient
+	ctx    context.Context
+	cancel context.CancelFunc
+	events chan wpasupplicant.WPAEvent
+}
+
+var _ wpasupplicant.Conn = (*client)(nil)
+
+// NewClient returns a wpasupplicant.Conn which calls the Supplicant service
+// on the specified gRPC connection, authenticating with token unless it is
+// empty.  Events are streamed into the Conn's EventQueue until Close() is
+// called, which does not close the gRPC connection itself.
+func NewClient(cc grpc.ClientConnInterface, token string) (wpasupplicant.Conn, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	c := &client{
+		c:      wpapb.NewSupplicantClient(cc),
+		ctx:    ctx,
+		cancel: cancel,
+		events: make(chan wpasupplicant.WPAEvent),
+	}
+
+	stream, err := c.c.Events(ctx, &wpapb.Empty{})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	go c.readEvents(stream)
+
+	return c, nil
+}
+
+// readEvents copies events from the stream to the EventQueue, until the
+// stream fails or the client is closed.
+func (c *client) readEvents(stream wpapb.Supplicant_EventsClient) {
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			return
+		}
+
+		select {
+		case c.events <- wpasupplicant.WPAEvent{
+			Event:     ev.GetEvent(),
+			Arguments: ev.GetArguments(),
+			Line:      ev.GetLine(),
+		}:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *client) EventQueue() chan wpasupplicant.WPAEvent {
+	return c.events
+}
+
+func (c *client) Close() error {
+	c.cancel()
+	return nil
+}
+
+func (c *client) Ping() error {
+	_, err := c.c.Ping(c.ctx, &wpapb.Empty{})
+	return err
+}
+
+func (c *client) Command(cmd string) (string, error) {
+	resp, err := c.c.Command(c.ctx, &wpapb.CommandRequest{Command: cmd})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetResponse(), nil
+}
+
+// statusResult implements wpasupplicant.StatusResult.
+type statusResult struct {
+	*wpapb.StatusResponse
+}
+
+func (r statusResult) WPAState() string        { return r.GetWpaState() }
+func (r statusResult) KeyMgmt() string         { return r.GetKeyMgmt() }
+func (r statusResult) IPAddr() string          { return r.GetIpAddr() }
+func (r statusResult) SSID() string            { return string(r.GetSsid()) }
+func (r statusResult) Address() string         { return r.GetAddress() }
+func (r statusResult) BSSID() net.HardwareAddr { return hardwareAddr(r.GetBssid()) }
+func (r statusResult) Frequency() int          { return int(r.GetFrequency()) }
+
+// hardwareAddr returns nil for an empty address.
+func hardwareAddr(b []byte) net.HardwareAddr {
+	if len(b) == 0 {
+		return nil
+	}
+	return net.HardwareAddr(b)
+}
+
+func (c *client) Status() (wpasupplicant.StatusResult, error) {
+	resp, err := c.c.Status(c.ctx, &wpapb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return statusResult{resp}, nil
+}
+
+// signalPollResult implements wpasupplicant.SignalPollResult.
+type signalPollResult struct {
+	*wpapb.SignalPollResponse
+}
+
+func (r signalPollResult) RSSI() int              { return int(r.GetRssi()) }
+func (r signalPollResult) LinkSpeed() int         { return int(r.GetLinkSpeed()) }
+func (r signalPollResult) Noise() int             { return int(r.GetNoise()) }
+func (r signalPollResult) Frequency() int         { return int(r.GetFrequency()) }
+func (r signalPollResult) Width() string          { return r.GetWidth() }
+func (r signalPollResult) CenterFrequency1() int  { return int(r.GetCenterFrequency1()) }
+func (r signalPollResult) CenterFrequency2() int  { return int(r.GetCenterFrequency2()) }
+func (r signalPollResult) AverageRSSI() int       { return int(r.GetAverageRssi()) }
+func (r signalPollResult) AverageBeaconRSSI() int { return int(r.GetAverageBeaconRssi()) }
+
+func (c *client) SignalPoll() (wpasupplicant.SignalPollResult, error) {
+	resp, err := c.c.SignalPoll(c.ctx, &wpapb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return signalPollResult{resp}, nil
+}
+
+// packetCountResult implements wpasupplicant.PacketCountResult.
+type packetCountResult struct {
+	*wpapb.PacketCountResponse
+}
+
+func (r packetCountResult) TxPackets() uint64  { return r.GetTxPackets() }
+func (r packetCountResult) TxFailures() uint64 { return r.GetTxFailures() }
+func (r packetCountResult) RxPackets() uint64  { return r.GetRxPackets() }
+
+func (c *client) PacketCountPoll() (wpasupplicant.PacketCountResult, error) {
+	resp, err := c.c.PacketCountPoll(c.ctx, &wpapb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return packetCountResult{resp}, nil
+}
+
+func (c *client) SignalMonitor(threshold, hysteresis int) error {
+	_, err := c.c.SignalMonitor(c.ctx, &wpapb.SignalMonitorRequest{
+		Threshold:  int32(threshold),
+		Hysteresis: int32(hysteresis),
+	})
+	return err
+}
+
+func (c *client) Scan() error {
+	_, err := c.c.Scan(c.ctx, &wpapb.Empty{})
+	return err
+}
+
+func (c *client) AbortScan() error {
+	_, err := c.c.AbortScan(c.ctx, &wpapb.Empty{})
+	return err
+}
+
+// scanResult implements wpasupplicant.ScanResult.
+type scanResult struct {
+	*wpapb.ScanResult
+}
+
+func (r scanResult) BSSID() net.HardwareAddr { return hardwareAddr(r.GetBssid()) }
+func (r scanResult) SSID() string            { return string(r.GetSsid()) }
+func (r scanResult) Frequency() int          { return int(r.GetFrequency()) }
+func (r scanResult) RSSI() int               { return int(r.GetRssi()) }
+func (r scanResult) Flags() []string         { return r.GetFlags() }
+
+func (c *client) ScanResults() ([]wpasupplicant.ScanResult, []error) {
+	resp, err := c.c.ScanResults(c.ctx, &wpapb.Empty{})
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var results []wpasupplicant.ScanResult
+	for _, r := range resp.GetResults() {
+		results = append(results, scanResult{r})
+	}
+	var errs []error
+	for _, e := range resp.GetErrors() {
+		errs = append(errs, errors.New(e))
+	}
+	return results, errs
+}
+
+func (c *client) SetScanInterval(interval int) error {
+	_, err := c.c.SetScanInterval(c.ctx, &wpapb.IntValue{Value: int32(interval)})
+	return err
+}
+
+func (c *client) Autoscan(spec string) error {
+	_, err := c.c.Autoscan(c.ctx, &wpapb.AutoscanRequest{Spec: spec})
+	return err
+}
+
+func (c *client) FlushBSS(age int) error {
+	_, err := c.c.FlushBSS(c.ctx, &wpapb.IntValue{Value: int32(age)})
+	return err
+}
+
+func (c *client) SetBSSExpireAge(age int) error {
+	_, err := c.c.SetBSSExpireAge(c.ctx, &wpapb.IntValue{Value: int32(age)})
+	return err
+}
+
+func (c *client) SetBSSExpireCount(count int) error {
+	_, err := c.c.SetBSSExpireCount(c.ctx, &wpapb.IntValue{Value: int32(count)})
+	return err
+}
+
+// configuredNetwork implements wpasupplicant.ConfiguredNetwork.
+type configuredNetwork struct {
+	*wpapb.ConfiguredNetwork
+}
+
+func (r configuredNetwork) NetworkID() int          { return int(r.GetId()) }
+func (r configuredNetwork) SSID() string            { return string(r.GetSsid()) }
+func (r configuredNetwork) BSSID() net.HardwareAddr { return hardwareAddr(r.GetBssid()) }
+func (r configuredNetwork) Flags() []string         { return r.GetFlags() }
+func (r configuredNetwork) Current() bool           { return r.hasFlag("CURRENT") }
+func (r configuredNetwork) Disabled() bool          { return r.hasFlag("DISABLED") }
+func (r configuredNetwork) TempDisabled() bool      { return r.hasFlag("TEMP-DISABLED") }
+func (r configuredNetwork) P2PPersistent() bool     { return r.hasFlag("P2P-PERSISTENT") }
+
+func (r configuredNetwork) hasFlag(flag string) bool {
+	for _, f := range r.GetFlags() {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *client) ListNetworks() ([]wpasupplicant.ConfiguredNetwork, error) {
+	resp, err := c.c.ListNetworks(c.ctx, &wpapb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var networks []wpasupplicant.ConfiguredNetwork
+	for _, n := range resp.GetNetworks() {
+		networks = append(networks, configuredNetwork{n})
+	}
+	return networks, nil
+}
+
+func (c *client) AddNetwork() (int, error) {
+	resp, err := c.c.AddNetwork(c.ctx, &wpapb.Empty{})
+	if err != nil {
+		return -1, err
+	}
+	return int(resp.GetId()), nil
+}
+
+func (c *client) SetNetwork(networkID int, variable, value string) error {
+	_, err := c.c.SetNetwork(c.ctx, &wpapb.SetNetworkRequest{
+		Id:       int32(networkID),
+		Variable: variable,
+		Value:    value,
+	})
+	return err
+}
+
+func (c *client) GetNetwork(networkID int, variable string) (string, error) {
+	resp, err := c.c.GetNetwork(c.ctx, &wpapb.GetNetworkRequest{
+		Id:       int32(networkID),
+		Variable: variable,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetValue(), nil
+}
+
+func (c *client) ApplyNetwork(networkID int, cfg wpasupplicant.NetworkConfig) error {
+	// Validate locally, so callers get the same errors as from a local
+	// Conn.
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	_, err := c.c.ApplyNetwork(c.ctx, &wpapb.ApplyNetworkRequest{
+		Id:     int32(networkID),
+		Config: toNetworkConfig(cfg),
+	})
+	return err
+}
+
+func (c *client) ReadNetwork(networkID int) (wpasupplicant.NetworkConfig, error) {
+	resp, err := c.c.ReadNetwork(c.ctx, &wpapb.NetworkID{Id: int32(networkID)})
+	if status.Code(err) == codes.NotFound {
+		return wpasupplicant.NetworkConfig{}, wpasupplicant.ErrNetworkNotFound
+	} else if err != nil {
+		return wpasupplicant.NetworkConfig{}, err
+	}
+	return fromNetworkConfig(resp)
+}
+
+func (c *client) EnableNetwork(networkID int) error {
+	_, err := c.c.EnableNetwork(c.ctx, &wpapb.NetworkID{Id: int32(networkID)})
+	return err
+}
+
+func (c *client) EnableAllNetworks() error {
+	_, err := c.c.EnableAllNetworks(c.ctx, &wpapb.Empty{})
+	return err
+}
+
+func (c *client) SelectNetwork(networkID int) error {
+	_, err := c.c.SelectNetwork(c.ctx, &wpapb.NetworkID{Id: int32(networkID)})
+	return err
+}
+
+func (c *client) DisableNetwork(networkID int) error {
+	_, err := c.c.DisableNetwork(c.ctx, &wpapb.NetworkID{Id: int32(networkID)})
+	return err
+}
+
+func (c *client) RemoveNetwork(networkID int) error {
+	_, err := c.c.RemoveNetwork(c.ctx, &wpapb.NetworkID{Id: int32(networkID)})
+	return err
+}
+
+func (c *client) RemoveAllNetworks() error {
+	_, err := c.c.RemoveAllNetworks(c.ctx, &wpapb.Empty{})
+	return err
+}
+
+func (c *client) Respond(req *wpasupplicant.CredentialRequest, value string) error {
+	_, err := c.c.Respond(c.ctx, &wpapb.RespondRequest{
+		Field:     string(req.Field),
+		NetworkId: int32(req.NetworkID),
+		Value:     value,
+	})
+	return err
+}
+
+func (c *client) SetBlob(name string, data []byte) error {
+	_, err := c.c.SetBlob(c.ctx, &wpapb.SetBlobRequest{Name: name, Data: data})
+	return err
+}
+
+func (c *client) SetSAEPWE(pwe wpasupplicant.SAEPWE) error {
+	_, err := c.c.SetSAEPWE(c.ctx, &wpapb.IntValue{Value: int32(pwe)})
+	return err
+}
+
+func (c *client) SaveConfig() error {
+	_, err := c.c.SaveConfig(c.ctx, &wpapb.Empty{})
+	return err
+}
+
+func (c *client) Reconfigure() error {
+	_, err := c.c.Reconfigure(c.ctx, &wpapb.Empty{})
+	return err
+}
+
+func (c *client) Reassociate() error {
+	_, err := c.c.Reassociate(c.ctx, &wpapb.Empty{})
+	return err
+}
+
+func (c *client) Reconnect() error {
+	_, err := c.c.Reconnect(c.ctx, &wpapb.Empty{})
+	return err
+}
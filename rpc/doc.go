@@ -0,0 +1,44 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package rpc exposes a wpasupplicant.Conn over gRPC, using the Supplicant
+// service defined in wpasupplicant.proto.
+//
+// NewServer adapts a local Conn to the service, and NewClient returns a
+// Conn which calls a remote one, so local and remote supplicants can be
+// used interchangeably.
+//
+// If a token is configured, calls must include it as a bearer token in the
+// authorization metadata, which NewClient does.
+//
+// The generated code in the wpapb subpackage is checked in.  After changing
+// wpasupplicant.proto, regenerate it by running go generate, which requires
+// protoc, protoc-gen-go and protoc-gen-go-grpc.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=module=pifke.org/wpasupplicant/rpc --go-grpc_out=. --go-grpc_opt=module=pifke.org/wpasupplicant/rpc wpasupplicant.proto
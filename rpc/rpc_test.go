@@ -0,0 +1,173 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"pifke.org/wpasupplicant"
+	"pifke.org/wpasupplicant/rpc/wpapb"
+)
+
+// fakeConn implements the parts of wpasupplicant.Conn used by the tests.
+type fakeConn struct {
+	wpasupplicant.Conn
+	events chan wpasupplicant.WPAEvent
+}
+
+func (c *fakeConn) EventQueue() chan wpasupplicant.WPAEvent { return c.events }
+
+func (c *fakeConn) Command(cmd string) (string, error) {
+	return "OK\n", nil
+}
+
+type fakeStatus struct {
+	wpasupplicant.StatusResult
+}
+
+func (fakeStatus) WPAState() string        { return "COMPLETED" }
+func (fakeStatus) KeyMgmt() string         { return "WPA2-PSK" }
+func (fakeStatus) IPAddr() string          { return "192.0.2.10" }
+func (fakeStatus) SSID() string            { return "caf\xe9" }
+func (fakeStatus) Address() string         { return "02:00:00:00:00:01" }
+func (fakeStatus) BSSID() net.HardwareAddr { return net.HardwareAddr{2, 0, 0, 0, 1, 0} }
+func (fakeStatus) Frequency() int          { return 5180 }
+
+func (c *fakeConn) Status() (wpasupplicant.StatusResult, error) {
+	return fakeStatus{}, nil
+}
+
+// newTestServer returns a gRPC connection to a running Server for a
+// fakeConn.
+func newTestServer(t *testing.T, token string) *grpc.ClientConn {
+	s := NewServer(&fakeConn{events: make(chan wpasupplicant.WPAEvent)}, token)
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.Run(ctx)
+
+	lis := bufconn.Listen(1 << 16)
+	g := grpc.NewServer()
+	wpapb.RegisterSupplicantServer(g, s)
+	go g.Serve(lis)
+
+	cc, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		cc.Close()
+		g.Stop()
+		cancel()
+	})
+	return cc
+}
+
+func TestClient(t *testing.T) {
+	c, err := NewClient(newTestServer(t, "s3cret"), "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	st, err := c.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.SSID() != "caf\xe9" || st.BSSID().String() != "02:00:00:00:01:00" || st.Frequency() != 5180 {
+		t.Errorf("wrong status: %q, %s, frequency %d", st.SSID(), st.BSSID(), st.Frequency())
+	}
+
+	if resp, err := c.Command("PING"); err != nil || resp != "OK\n" {
+		t.Errorf("got %q, %v", resp, err)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	cc := newTestServer(t, "s3cret")
+
+	for _, token := range []string{"", "wrong"} {
+		c, err := NewClient(cc, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Command("TERMINATE")
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("token %q: expected Unauthenticated, got %v", token, err)
+		}
+		c.Close()
+	}
+}
+
+func TestNetworkConfig(t *testing.T) {
+	cfg := wpasupplicant.NetworkConfig{
+		SSID:     "home \"wifi\"",
+		ScanSSID: true,
+		KeyMgmt:  "WPA-PSK SAE",
+		PSK:      "correct horse",
+		Priority: 5,
+		EAPConfig: wpasupplicant.EAPConfig{
+			Identity: "user",
+		},
+	}
+
+	pb := toNetworkConfig(cfg)
+	expect := map[string]string{
+		"ssid":      `"home "wifi""`,
+		"scan_ssid": "1",
+		"key_mgmt":  "WPA-PSK SAE",
+		"psk":       `"correct horse"`,
+		"priority":  "5",
+		"identity":  `"user"`,
+	}
+	if !reflect.DeepEqual(pb.GetVariables(), expect) {
+		t.Errorf("got %v, expected %v", pb.GetVariables(), expect)
+	}
+
+	got, err := fromNetworkConfig(pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, cfg) {
+		t.Errorf("round trip: got %+v, expected %+v", got, cfg)
+	}
+}
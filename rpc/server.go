@@ -0,0 +1,485 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"pifke.org/wpasupplicant"
+	"pifke.org/wpasupplicant/rpc/wpapb"
+)
+
+// Server implements the Supplicant service for a wpasupplicant.Conn.
+// Register it using wpapb.RegisterSupplicantServer().
+type Server struct {
+	wpapb.UnimplementedSupplicantServer
+
+	conn   wpasupplicant.Conn
+	token  string
+	events *wpasupplicant.EventBroadcaster
+}
+
+var _ wpapb.SupplicantServer = (*Server)(nil)
+
+// NewServer returns a Server for the specified Conn.  If token is empty,
+// calls are not authenticated.
+func NewServer(c wpasupplicant.Conn, token string) *Server {
+	return &Server{
+		conn:   c,
+		token:  token,
+		events: wpasupplicant.NewEventBroadcaster(c),
+	}
+}
+
+// Run distributes events from the Conn's EventQueue to Events calls, until
+// the context is done.  It must be running for the server to work.
+// Callers should not be reading events from the EventQueue at the same
+// time.
+func (s *Server) Run(ctx context.Context) error {
+	return s.events.Run(ctx)
+}
+
+// authorize checks the call's token.  Since Command can send any control
+// command, every call is checked, the same as the REST server.
+func (s *Server) authorize(ctx context.Context) error {
+	if s.token == "" {
+		return nil
+	}
+
+	var token string
+	md, _ := metadata.FromIncomingContext(ctx)
+	if auth := md.Get("authorization"); len(auth) > 0 && strings.HasPrefix(auth[0], "Bearer ") {
+		token = strings.TrimPrefix(auth[0], "Bearer ")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	return nil
+}
+
+// rpcError converts an error from the Conn to a gRPC status.
+func rpcError(err error) error {
+	switch err.(type) {
+	case nil:
+		return nil
+	case *wpasupplicant.ConfigError:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	switch err {
+	case wpasupplicant.ErrPassphraseLength, wpasupplicant.ErrPassphraseCharset,
+		wpasupplicant.ErrEmptySSID, wpasupplicant.ErrEmptySAEPassword:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case wpasupplicant.ErrNetworkNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// empty returns the response to an RPC with no result.
+func empty(err error) (*wpapb.Empty, error) {
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return &wpapb.Empty{}, nil
+}
+
+func (s *Server) Ping(ctx context.Context, req *wpapb.Empty) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.Ping())
+}
+
+func (s *Server) Command(ctx context.Context, req *wpapb.CommandRequest) (*wpapb.CommandResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := s.conn.Command(req.GetCommand())
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return &wpapb.CommandResponse{Response: resp}, nil
+}
+
+func (s *Server) Status(ctx context.Context, req *wpapb.Empty) (*wpapb.StatusResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	st, err := s.conn.Status()
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	return &wpapb.StatusResponse{
+		WpaState:  st.WPAState(),
+		KeyMgmt:   st.KeyMgmt(),
+		IpAddr:    st.IPAddr(),
+		Ssid:      []byte(st.SSID()),
+		Address:   st.Address(),
+		Bssid:     st.BSSID(),
+		Frequency: int32(st.Frequency()),
+	}, nil
+}
+
+func (s *Server) SignalPoll(ctx context.Context, req *wpapb.Empty) (*wpapb.SignalPollResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	r, err := s.conn.SignalPoll()
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	return &wpapb.SignalPollResponse{
+		Rssi:              int32(r.RSSI()),
+		LinkSpeed:         int32(r.LinkSpeed()),
+		Noise:             int32(r.Noise()),
+		Frequency:         int32(r.Frequency()),
+		Width:             r.Width(),
+		CenterFrequency1:  int32(r.CenterFrequency1()),
+		CenterFrequency2:  int32(r.CenterFrequency2()),
+		AverageRssi:       int32(r.AverageRSSI()),
+		AverageBeaconRssi: int32(r.AverageBeaconRSSI()),
+	}, nil
+}
+
+func (s *Server) PacketCountPoll(ctx context.Context, req *wpapb.Empty) (*wpapb.PacketCountResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	r, err := s.conn.PacketCountPoll()
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	return &wpapb.PacketCountResponse{
+		TxPackets:  r.TxPackets(),
+		TxFailures: r.TxFailures(),
+		RxPackets:  r.RxPackets(),
+	}, nil
+}
+
+func (s *Server) SignalMonitor(ctx context.Context, req *wpapb.SignalMonitorRequest) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.SignalMonitor(int(req.GetThreshold()), int(req.GetHysteresis())))
+}
+
+func (s *Server) Scan(ctx context.Context, req *wpapb.Empty) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.Scan())
+}
+
+func (s *Server) AbortScan(ctx context.Context, req *wpapb.Empty) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.AbortScan())
+}
+
+func (s *Server) ScanResults(ctx context.Context, req *wpapb.Empty) (*wpapb.ScanResultsResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	results, errs := s.conn.ScanResults()
+
+	resp := &wpapb.ScanResultsResponse{}
+	for _, r := range results {
+		resp.Results = append(resp.Results, &wpapb.ScanResult{
+			Bssid:     r.BSSID(),
+			Ssid:      []byte(r.SSID()),
+			Frequency: int32(r.Frequency()),
+			Rssi:      int32(r.RSSI()),
+			Flags:     r.Flags(),
+		})
+	}
+	for _, err := range errs {
+		resp.Errors = append(resp.Errors, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *Server) SetScanInterval(ctx context.Context, req *wpapb.IntValue) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.SetScanInterval(int(req.GetValue())))
+}
+
+func (s *Server) Autoscan(ctx context.Context, req *wpapb.AutoscanRequest) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.Autoscan(req.GetSpec()))
+}
+
+func (s *Server) FlushBSS(ctx context.Context, req *wpapb.IntValue) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.FlushBSS(int(req.GetValue())))
+}
+
+func (s *Server) SetBSSExpireAge(ctx context.Context, req *wpapb.IntValue) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.SetBSSExpireAge(int(req.GetValue())))
+}
+
+func (s *Server) SetBSSExpireCount(ctx context.Context, req *wpapb.IntValue) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.SetBSSExpireCount(int(req.GetValue())))
+}
+
+func (s *Server) ListNetworks(ctx context.Context, req *wpapb.Empty) (*wpapb.ListNetworksResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	networks, err := s.conn.ListNetworks()
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	resp := &wpapb.ListNetworksResponse{}
+	for _, n := range networks {
+		resp.Networks = append(resp.Networks, &wpapb.ConfiguredNetwork{
+			Id:    int32(n.NetworkID()),
+			Ssid:  []byte(n.SSID()),
+			Bssid: n.BSSID(),
+			Flags: n.Flags(),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) AddNetwork(ctx context.Context, req *wpapb.Empty) (*wpapb.NetworkID, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	id, err := s.conn.AddNetwork()
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return &wpapb.NetworkID{Id: int32(id)}, nil
+}
+
+func (s *Server) SetNetwork(ctx context.Context, req *wpapb.SetNetworkRequest) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.SetNetwork(int(req.GetId()), req.GetVariable(), req.GetValue()))
+}
+
+func (s *Server) GetNetwork(ctx context.Context, req *wpapb.GetNetworkRequest) (*wpapb.GetNetworkResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	value, err := s.conn.GetNetwork(int(req.GetId()), req.GetVariable())
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return &wpapb.GetNetworkResponse{Value: value}, nil
+}
+
+func (s *Server) ApplyNetwork(ctx context.Context, req *wpapb.ApplyNetworkRequest) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	cfg, err := fromNetworkConfig(req.GetConfig())
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return empty(s.conn.ApplyNetwork(int(req.GetId()), cfg))
+}
+
+func (s *Server) ReadNetwork(ctx context.Context, req *wpapb.NetworkID) (*wpapb.NetworkConfig, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	cfg, err := s.conn.ReadNetwork(int(req.GetId()))
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return toNetworkConfig(cfg), nil
+}
+
+func (s *Server) EnableNetwork(ctx context.Context, req *wpapb.NetworkID) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.EnableNetwork(int(req.GetId())))
+}
+
+func (s *Server) EnableAllNetworks(ctx context.Context, req *wpapb.Empty) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.EnableAllNetworks())
+}
+
+func (s *Server) SelectNetwork(ctx context.Context, req *wpapb.NetworkID) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.SelectNetwork(int(req.GetId())))
+}
+
+func (s *Server) DisableNetwork(ctx context.Context, req *wpapb.NetworkID) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.DisableNetwork(int(req.GetId())))
+}
+
+func (s *Server) RemoveNetwork(ctx context.Context, req *wpapb.NetworkID) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.RemoveNetwork(int(req.GetId())))
+}
+
+func (s *Server) RemoveAllNetworks(ctx context.Context, req *wpapb.Empty) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.RemoveAllNetworks())
+}
+
+func (s *Server) Respond(ctx context.Context, req *wpapb.RespondRequest) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.Respond(&wpasupplicant.CredentialRequest{
+		Field:     wpasupplicant.CredentialField(req.GetField()),
+		NetworkID: int(req.GetNetworkId()),
+	}, req.GetValue()))
+}
+
+func (s *Server) SetBlob(ctx context.Context, req *wpapb.SetBlobRequest) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.SetBlob(req.GetName(), req.GetData()))
+}
+
+func (s *Server) SetSAEPWE(ctx context.Context, req *wpapb.IntValue) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.SetSAEPWE(wpasupplicant.SAEPWE(req.GetValue())))
+}
+
+func (s *Server) SaveConfig(ctx context.Context, req *wpapb.Empty) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.SaveConfig())
+}
+
+func (s *Server) Reconfigure(ctx context.Context, req *wpapb.Empty) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.Reconfigure())
+}
+
+func (s *Server) Reassociate(ctx context.Context, req *wpapb.Empty) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.Reassociate())
+}
+
+func (s *Server) Reconnect(ctx context.Context, req *wpapb.Empty) (*wpapb.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return empty(s.conn.Reconnect())
+}
+
+func (s *Server) Events(req *wpapb.Empty, stream wpapb.Supplicant_EventsServer) error {
+	if err := s.authorize(stream.Context()); err != nil {
+		return err
+	}
+
+	sub := s.events.Subscribe()
+	defer s.events.Unsubscribe(sub)
+
+	for {
+		select {
+		case ev := <-sub.EventQueue():
+			ev = ev.Redacted()
+			err := stream.Send(&wpapb.Event{
+				Event:     ev.Event,
+				Arguments: ev.Arguments,
+				Line:      ev.Line,
+			})
+			if err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// toNetworkConfig converts a NetworkConfig to its protobuf form.
+func toNetworkConfig(cfg wpasupplicant.NetworkConfig) *wpapb.NetworkConfig {
+	pb := &wpapb.NetworkConfig{Variables: make(map[string]string)}
+	for _, name := range wpasupplicant.NetworkVariables() {
+		if value, ok := cfg.Get(name); ok {
+			pb.Variables[name] = value
+		}
+	}
+	return pb
+}
+
+// fromNetworkConfig converts a NetworkConfig from its protobuf form.
+func fromNetworkConfig(pb *wpapb.NetworkConfig) (wpasupplicant.NetworkConfig, error) {
+	var cfg wpasupplicant.NetworkConfig
+	for name, value := range pb.GetVariables() {
+		if err := cfg.Set(name, value); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,2062 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: wpasupplicant.proto
+
+package wpapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{0}
+}
+
+type IntValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value int32 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *IntValue) Reset() {
+	*x = IntValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntValue) ProtoMessage() {}
+
+func (x *IntValue) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntValue.ProtoReflect.Descriptor instead.
+func (*IntValue) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IntValue) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type CommandRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+}
+
+func (x *CommandRequest) Reset() {
+	*x = CommandRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandRequest) ProtoMessage() {}
+
+func (x *CommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandRequest.ProtoReflect.Descriptor instead.
+func (*CommandRequest) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CommandRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+type CommandResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Response string `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (x *CommandResponse) Reset() {
+	*x = CommandResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResponse) ProtoMessage() {}
+
+func (x *CommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResponse.ProtoReflect.Descriptor instead.
+func (*CommandResponse) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CommandResponse) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+type NetworkID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *NetworkID) Reset() {
+	*x = NetworkID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkID) ProtoMessage() {}
+
+func (x *NetworkID) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkID.ProtoReflect.Descriptor instead.
+func (*NetworkID) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NetworkID) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WpaState string `protobuf:"bytes,1,opt,name=wpa_state,json=wpaState,proto3" json:"wpa_state,omitempty"`
+	KeyMgmt  string `protobuf:"bytes,2,opt,name=key_mgmt,json=keyMgmt,proto3" json:"key_mgmt,omitempty"`
+	IpAddr   string `protobuf:"bytes,3,opt,name=ip_addr,json=ipAddr,proto3" json:"ip_addr,omitempty"`
+	// ssid is the raw SSID, which may contain arbitrary bytes.
+	Ssid    []byte `protobuf:"bytes,4,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Address string `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	// bssid is empty if not associated.
+	Bssid     []byte `protobuf:"bytes,6,opt,name=bssid,proto3" json:"bssid,omitempty"`
+	Frequency int32  `protobuf:"varint,7,opt,name=frequency,proto3" json:"frequency,omitempty"`
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StatusResponse) GetWpaState() string {
+	if x != nil {
+		return x.WpaState
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetKeyMgmt() string {
+	if x != nil {
+		return x.KeyMgmt
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetIpAddr() string {
+	if x != nil {
+		return x.IpAddr
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetSsid() []byte {
+	if x != nil {
+		return x.Ssid
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetBssid() []byte {
+	if x != nil {
+		return x.Bssid
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetFrequency() int32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+type SignalPollResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rssi              int32  `protobuf:"varint,1,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	LinkSpeed         int32  `protobuf:"varint,2,opt,name=link_speed,json=linkSpeed,proto3" json:"link_speed,omitempty"`
+	Noise             int32  `protobuf:"varint,3,opt,name=noise,proto3" json:"noise,omitempty"`
+	Frequency         int32  `protobuf:"varint,4,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Width             string `protobuf:"bytes,5,opt,name=width,proto3" json:"width,omitempty"`
+	CenterFrequency1  int32  `protobuf:"varint,6,opt,name=center_frequency1,json=centerFrequency1,proto3" json:"center_frequency1,omitempty"`
+	CenterFrequency2  int32  `protobuf:"varint,7,opt,name=center_frequency2,json=centerFrequency2,proto3" json:"center_frequency2,omitempty"`
+	AverageRssi       int32  `protobuf:"varint,8,opt,name=average_rssi,json=averageRssi,proto3" json:"average_rssi,omitempty"`
+	AverageBeaconRssi int32  `protobuf:"varint,9,opt,name=average_beacon_rssi,json=averageBeaconRssi,proto3" json:"average_beacon_rssi,omitempty"`
+}
+
+func (x *SignalPollResponse) Reset() {
+	*x = SignalPollResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalPollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalPollResponse) ProtoMessage() {}
+
+func (x *SignalPollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalPollResponse.ProtoReflect.Descriptor instead.
+func (*SignalPollResponse) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SignalPollResponse) GetRssi() int32 {
+	if x != nil {
+		return x.Rssi
+	}
+	return 0
+}
+
+func (x *SignalPollResponse) GetLinkSpeed() int32 {
+	if x != nil {
+		return x.LinkSpeed
+	}
+	return 0
+}
+
+func (x *SignalPollResponse) GetNoise() int32 {
+	if x != nil {
+		return x.Noise
+	}
+	return 0
+}
+
+func (x *SignalPollResponse) GetFrequency() int32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *SignalPollResponse) GetWidth() string {
+	if x != nil {
+		return x.Width
+	}
+	return ""
+}
+
+func (x *SignalPollResponse) GetCenterFrequency1() int32 {
+	if x != nil {
+		return x.CenterFrequency1
+	}
+	return 0
+}
+
+func (x *SignalPollResponse) GetCenterFrequency2() int32 {
+	if x != nil {
+		return x.CenterFrequency2
+	}
+	return 0
+}
+
+func (x *SignalPollResponse) GetAverageRssi() int32 {
+	if x != nil {
+		return x.AverageRssi
+	}
+	return 0
+}
+
+func (x *SignalPollResponse) GetAverageBeaconRssi() int32 {
+	if x != nil {
+		return x.AverageBeaconRssi
+	}
+	return 0
+}
+
+type PacketCountResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxPackets  uint64 `protobuf:"varint,1,opt,name=tx_packets,json=txPackets,proto3" json:"tx_packets,omitempty"`
+	TxFailures uint64 `protobuf:"varint,2,opt,name=tx_failures,json=txFailures,proto3" json:"tx_failures,omitempty"`
+	RxPackets  uint64 `protobuf:"varint,3,opt,name=rx_packets,json=rxPackets,proto3" json:"rx_packets,omitempty"`
+}
+
+func (x *PacketCountResponse) Reset() {
+	*x = PacketCountResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PacketCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PacketCountResponse) ProtoMessage() {}
+
+func (x *PacketCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PacketCountResponse.ProtoReflect.Descriptor instead.
+func (*PacketCountResponse) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PacketCountResponse) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *PacketCountResponse) GetTxFailures() uint64 {
+	if x != nil {
+		return x.TxFailures
+	}
+	return 0
+}
+
+func (x *PacketCountResponse) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+type SignalMonitorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Threshold  int32 `protobuf:"varint,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Hysteresis int32 `protobuf:"varint,2,opt,name=hysteresis,proto3" json:"hysteresis,omitempty"`
+}
+
+func (x *SignalMonitorRequest) Reset() {
+	*x = SignalMonitorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalMonitorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalMonitorRequest) ProtoMessage() {}
+
+func (x *SignalMonitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalMonitorRequest.ProtoReflect.Descriptor instead.
+func (*SignalMonitorRequest) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SignalMonitorRequest) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *SignalMonitorRequest) GetHysteresis() int32 {
+	if x != nil {
+		return x.Hysteresis
+	}
+	return 0
+}
+
+type ScanResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bssid     []byte   `protobuf:"bytes,1,opt,name=bssid,proto3" json:"bssid,omitempty"`
+	Ssid      []byte   `protobuf:"bytes,2,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Frequency int32    `protobuf:"varint,3,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Rssi      int32    `protobuf:"varint,4,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	Flags     []string `protobuf:"bytes,5,rep,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *ScanResult) Reset() {
+	*x = ScanResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScanResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResult) ProtoMessage() {}
+
+func (x *ScanResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResult.ProtoReflect.Descriptor instead.
+func (*ScanResult) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ScanResult) GetBssid() []byte {
+	if x != nil {
+		return x.Bssid
+	}
+	return nil
+}
+
+func (x *ScanResult) GetSsid() []byte {
+	if x != nil {
+		return x.Ssid
+	}
+	return nil
+}
+
+func (x *ScanResult) GetFrequency() int32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *ScanResult) GetRssi() int32 {
+	if x != nil {
+		return x.Rssi
+	}
+	return 0
+}
+
+func (x *ScanResult) GetFlags() []string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type ScanResultsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*ScanResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// errors describes lines of the scan results which couldn't be
+	// parsed.
+	Errors []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *ScanResultsResponse) Reset() {
+	*x = ScanResultsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScanResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResultsResponse) ProtoMessage() {}
+
+func (x *ScanResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResultsResponse.ProtoReflect.Descriptor instead.
+func (*ScanResultsResponse) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ScanResultsResponse) GetResults() []*ScanResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ScanResultsResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type AutoscanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spec string `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+}
+
+func (x *AutoscanRequest) Reset() {
+	*x = AutoscanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AutoscanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoscanRequest) ProtoMessage() {}
+
+func (x *AutoscanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoscanRequest.ProtoReflect.Descriptor instead.
+func (*AutoscanRequest) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AutoscanRequest) GetSpec() string {
+	if x != nil {
+		return x.Spec
+	}
+	return ""
+}
+
+type ConfiguredNetwork struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ssid []byte `protobuf:"bytes,2,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	// bssid is empty for any BSSID.
+	Bssid []byte   `protobuf:"bytes,3,opt,name=bssid,proto3" json:"bssid,omitempty"`
+	Flags []string `protobuf:"bytes,4,rep,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *ConfiguredNetwork) Reset() {
+	*x = ConfiguredNetwork{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfiguredNetwork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfiguredNetwork) ProtoMessage() {}
+
+func (x *ConfiguredNetwork) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfiguredNetwork.ProtoReflect.Descriptor instead.
+func (*ConfiguredNetwork) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConfiguredNetwork) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConfiguredNetwork) GetSsid() []byte {
+	if x != nil {
+		return x.Ssid
+	}
+	return nil
+}
+
+func (x *ConfiguredNetwork) GetBssid() []byte {
+	if x != nil {
+		return x.Bssid
+	}
+	return nil
+}
+
+func (x *ConfiguredNetwork) GetFlags() []string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type ListNetworksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Networks []*ConfiguredNetwork `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+}
+
+func (x *ListNetworksResponse) Reset() {
+	*x = ListNetworksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNetworksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNetworksResponse) ProtoMessage() {}
+
+func (x *ListNetworksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNetworksResponse.ProtoReflect.Descriptor instead.
+func (*ListNetworksResponse) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListNetworksResponse) GetNetworks() []*ConfiguredNetwork {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+type SetNetworkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Variable string `protobuf:"bytes,2,opt,name=variable,proto3" json:"variable,omitempty"`
+	Value    string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *SetNetworkRequest) Reset() {
+	*x = SetNetworkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetNetworkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNetworkRequest) ProtoMessage() {}
+
+func (x *SetNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNetworkRequest.ProtoReflect.Descriptor instead.
+func (*SetNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetNetworkRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetNetworkRequest) GetVariable() string {
+	if x != nil {
+		return x.Variable
+	}
+	return ""
+}
+
+func (x *SetNetworkRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type GetNetworkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Variable string `protobuf:"bytes,2,opt,name=variable,proto3" json:"variable,omitempty"`
+}
+
+func (x *GetNetworkRequest) Reset() {
+	*x = GetNetworkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNetworkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetworkRequest) ProtoMessage() {}
+
+func (x *GetNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetworkRequest.ProtoReflect.Descriptor instead.
+func (*GetNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetNetworkRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetNetworkRequest) GetVariable() string {
+	if x != nil {
+		return x.Variable
+	}
+	return ""
+}
+
+type GetNetworkResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *GetNetworkResponse) Reset() {
+	*x = GetNetworkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNetworkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetworkResponse) ProtoMessage() {}
+
+func (x *GetNetworkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetworkResponse.ProtoReflect.Descriptor instead.
+func (*GetNetworkResponse) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetNetworkResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// NetworkConfig is a wpasupplicant.NetworkConfig.  Variables with their
+// zero value are omitted, and the others are encoded as in
+// wpa_supplicant.conf.
+type NetworkConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Variables map[string]string `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *NetworkConfig) Reset() {
+	*x = NetworkConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkConfig) ProtoMessage() {}
+
+func (x *NetworkConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkConfig.ProtoReflect.Descriptor instead.
+func (*NetworkConfig) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NetworkConfig) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type ApplyNetworkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     int32          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Config *NetworkConfig `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *ApplyNetworkRequest) Reset() {
+	*x = ApplyNetworkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyNetworkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyNetworkRequest) ProtoMessage() {}
+
+func (x *ApplyNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyNetworkRequest.ProtoReflect.Descriptor instead.
+func (*ApplyNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ApplyNetworkRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ApplyNetworkRequest) GetConfig() *NetworkConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type RespondRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field     string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	NetworkId int32  `protobuf:"varint,2,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	Value     string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *RespondRequest) Reset() {
+	*x = RespondRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespondRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondRequest) ProtoMessage() {}
+
+func (x *RespondRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondRequest.ProtoReflect.Descriptor instead.
+func (*RespondRequest) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RespondRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *RespondRequest) GetNetworkId() int32 {
+	if x != nil {
+		return x.NetworkId
+	}
+	return 0
+}
+
+func (x *RespondRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SetBlobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *SetBlobRequest) Reset() {
+	*x = SetBlobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetBlobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBlobRequest) ProtoMessage() {}
+
+func (x *SetBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBlobRequest.ProtoReflect.Descriptor instead.
+func (*SetBlobRequest) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetBlobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetBlobRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event     string            `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Arguments map[string]string `protobuf:"bytes,2,rep,name=arguments,proto3" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Line      string            `protobuf:"bytes,3,opt,name=line,proto3" json:"line,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wpasupplicant_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_wpasupplicant_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_wpasupplicant_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Event) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *Event) GetArguments() map[string]string {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *Event) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+var File_wpasupplicant_proto protoreflect.FileDescriptor
+
+var file_wpasupplicant_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x20, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x22, 0x2a, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0x2d,
+	0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x0a,
+	0x09, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0xc3, 0x01, 0x0a, 0x0e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x77, 0x70, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x77, 0x70, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65,
+	0x79, 0x5f, 0x6d, 0x67, 0x6d, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65,
+	0x79, 0x4d, 0x67, 0x6d, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x73,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x62, 0x73, 0x73, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x73, 0x73,
+	0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79,
+	0x22, 0xbe, 0x02, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50, 0x6f, 0x6c, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x73, 0x73, 0x69, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x73, 0x73, 0x69, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
+	0x69, 0x6e, 0x6b, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f,
+	0x69, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6e, 0x6f, 0x69, 0x73, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x66,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x31, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x10, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79,
+	0x31, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x79, 0x32, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x63, 0x65,
+	0x6e, 0x74, 0x65, 0x72, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x32, 0x12, 0x21,
+	0x0a, 0x0c, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x73, 0x73, 0x69, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x73, 0x73,
+	0x69, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x5f, 0x72, 0x73, 0x73, 0x69, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11,
+	0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x73, 0x73,
+	0x69, 0x22, 0x74, 0x0a, 0x13, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x78, 0x5f, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x78, 0x5f, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x78,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x78, 0x5f, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x78,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x54, 0x0a, 0x14, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x68, 0x79, 0x73, 0x74, 0x65, 0x72, 0x65, 0x73, 0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x68, 0x79, 0x73, 0x74, 0x65, 0x72, 0x65, 0x73, 0x69, 0x73, 0x22, 0x7e, 0x0a,
+	0x0a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62,
+	0x73, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x73, 0x73, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x73, 0x73, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x73, 0x73, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x72, 0x73, 0x73, 0x69, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22, 0x65, 0x0a,
+	0x13, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x22, 0x25, 0x0a, 0x0f, 0x41, 0x75, 0x74, 0x6f, 0x73, 0x63, 0x61, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x63, 0x0a, 0x11, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x73, 0x73, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x73, 0x73, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x73, 0x73, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73,
+	0x22, 0x57, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x77, 0x70, 0x61,
+	0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52,
+	0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x22, 0x55, 0x0a, 0x11, 0x53, 0x65, 0x74,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x3f, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x22, 0x2a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x9b, 0x01,
+	0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x4c, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x1a, 0x3c, 0x0a,
+	0x0e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5e, 0x0a, 0x13, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x37, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x5b, 0x0a, 0x0e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x38, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x42,
+	0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0xb5, 0x01, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x44, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x41,
+	0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x61,
+	0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x3c, 0x0a, 0x0e,
+	0x41, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x87, 0x13, 0x0a, 0x0a, 0x53,
+	0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x04, 0x50, 0x69, 0x6e,
+	0x67, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61,
+	0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x4e, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x20,
+	0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x2e,
+	0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x20, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0a, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x6c, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x24, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x25, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x6c, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x26, 0x2e, 0x77, 0x70, 0x61, 0x73,
+	0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x6c, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x04, 0x53, 0x63,
+	0x61, 0x6e, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x77, 0x70,
+	0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x09, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x53, 0x63, 0x61,
+	0x6e, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61,
+	0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x4d, 0x0a, 0x0b, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x25, 0x2e, 0x77, 0x70,
+	0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x46, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1a, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x46, 0x0a, 0x08, 0x41, 0x75,
+	0x74, 0x6f, 0x73, 0x63, 0x61, 0x6e, 0x12, 0x21, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x73, 0x63,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73,
+	0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x3f, 0x0a, 0x08, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x42, 0x53, 0x53, 0x12, 0x1a,
+	0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61,
+	0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x46, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x42, 0x53, 0x53, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x41, 0x67, 0x65, 0x12, 0x1a, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x48, 0x0a, 0x11, 0x53,
+	0x65, 0x74, 0x42, 0x53, 0x53, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1a, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x17, 0x2e, 0x77,
+	0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4f, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x26,
+	0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e,
+	0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x12, 0x4a, 0x0a, 0x0a, 0x53, 0x65,
+	0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x23, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x57, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x12, 0x23, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x77, 0x70, 0x61, 0x73,
+	0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4e, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12,
+	0x25, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x4b, 0x0a, 0x0b, 0x52, 0x65, 0x61, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1b,
+	0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x1a, 0x1f, 0x2e, 0x77, 0x70,
+	0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x45, 0x0a, 0x0d,
+	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1b, 0x2e,
+	0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61,
+	0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x45, 0x0a, 0x11, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x6c, 0x6c,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x45, 0x0a, 0x0d, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1b, 0x2e, 0x77, 0x70,
+	0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x46, 0x0a, 0x0e, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x12, 0x1b, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44,
+	0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x45, 0x0a, 0x0d, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1b, 0x2e, 0x77, 0x70, 0x61,
+	0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x45, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x6c, 0x6c, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17,
+	0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x64, 0x12, 0x20, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a,
+	0x07, 0x53, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x20, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x42,
+	0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61,
+	0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x40, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x53, 0x41, 0x45, 0x50, 0x57, 0x45,
+	0x12, 0x1a, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x17, 0x2e, 0x77,
+	0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x0a, 0x53, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x77,
+	0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e,
+	0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x0b, 0x52, 0x65, 0x61, 0x73, 0x73, 0x6f,
+	0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17,
+	0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e,
+	0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x77, 0x70, 0x61, 0x73,
+	0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x30, 0x01, 0x42, 0x23, 0x5a, 0x21, 0x70, 0x69, 0x66, 0x6b, 0x65, 0x2e, 0x6f, 0x72,
+	0x67, 0x2f, 0x77, 0x70, 0x61, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x6e, 0x74, 0x2f,
+	0x72, 0x70, 0x63, 0x2f, 0x77, 0x70, 0x61, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_wpasupplicant_proto_rawDescOnce sync.Once
+	file_wpasupplicant_proto_rawDescData = file_wpasupplicant_proto_rawDesc
+)
+
+func file_wpasupplicant_proto_rawDescGZIP() []byte {
+	file_wpasupplicant_proto_rawDescOnce.Do(func() {
+		file_wpasupplicant_proto_rawDescData = protoimpl.X.CompressGZIP(file_wpasupplicant_proto_rawDescData)
+	})
+	return file_wpasupplicant_proto_rawDescData
+}
+
+var file_wpasupplicant_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_wpasupplicant_proto_goTypes = []any{
+	(*Empty)(nil),                // 0: wpasupplicant.v1.Empty
+	(*IntValue)(nil),             // 1: wpasupplicant.v1.IntValue
+	(*CommandRequest)(nil),       // 2: wpasupplicant.v1.CommandRequest
+	(*CommandResponse)(nil),      // 3: wpasupplicant.v1.CommandResponse
+	(*NetworkID)(nil),            // 4: wpasupplicant.v1.NetworkID
+	(*StatusResponse)(nil),       // 5: wpasupplicant.v1.StatusResponse
+	(*SignalPollResponse)(nil),   // 6: wpasupplicant.v1.SignalPollResponse
+	(*PacketCountResponse)(nil),  // 7: wpasupplicant.v1.PacketCountResponse
+	(*SignalMonitorRequest)(nil), // 8: wpasupplicant.v1.SignalMonitorRequest
+	(*ScanResult)(nil),           // 9: wpasupplicant.v1.ScanResult
+	(*ScanResultsResponse)(nil),  // 10: wpasupplicant.v1.ScanResultsResponse
+	(*AutoscanRequest)(nil),      // 11: wpasupplicant.v1.AutoscanRequest
+	(*ConfiguredNetwork)(nil),    // 12: wpasupplicant.v1.ConfiguredNetwork
+	(*ListNetworksResponse)(nil), // 13: wpasupplicant.v1.ListNetworksResponse
+	(*SetNetworkRequest)(nil),    // 14: wpasupplicant.v1.SetNetworkRequest
+	(*GetNetworkRequest)(nil),    // 15: wpasupplicant.v1.GetNetworkRequest
+	(*GetNetworkResponse)(nil),   // 16: wpasupplicant.v1.GetNetworkResponse
+	(*NetworkConfig)(nil),        // 17: wpasupplicant.v1.NetworkConfig
+	(*ApplyNetworkRequest)(nil),  // 18: wpasupplicant.v1.ApplyNetworkRequest
+	(*RespondRequest)(nil),       // 19: wpasupplicant.v1.RespondRequest
+	(*SetBlobRequest)(nil),       // 20: wpasupplicant.v1.SetBlobRequest
+	(*Event)(nil),                // 21: wpasupplicant.v1.Event
+	nil,                          // 22: wpasupplicant.v1.NetworkConfig.VariablesEntry
+	nil,                          // 23: wpasupplicant.v1.Event.ArgumentsEntry
+}
+var file_wpasupplicant_proto_depIdxs = []int32{
+	9,  // 0: wpasupplicant.v1.ScanResultsResponse.results:type_name -> wpasupplicant.v1.ScanResult
+	12, // 1: wpasupplicant.v1.ListNetworksResponse.networks:type_name -> wpasupplicant.v1.ConfiguredNetwork
+	22, // 2: wpasupplicant.v1.NetworkConfig.variables:type_name -> wpasupplicant.v1.NetworkConfig.VariablesEntry
+	17, // 3: wpasupplicant.v1.ApplyNetworkRequest.config:type_name -> wpasupplicant.v1.NetworkConfig
+	23, // 4: wpasupplicant.v1.Event.arguments:type_name -> wpasupplicant.v1.Event.ArgumentsEntry
+	0,  // 5: wpasupplicant.v1.Supplicant.Ping:input_type -> wpasupplicant.v1.Empty
+	2,  // 6: wpasupplicant.v1.Supplicant.Command:input_type -> wpasupplicant.v1.CommandRequest
+	0,  // 7: wpasupplicant.v1.Supplicant.Status:input_type -> wpasupplicant.v1.Empty
+	0,  // 8: wpasupplicant.v1.Supplicant.SignalPoll:input_type -> wpasupplicant.v1.Empty
+	0,  // 9: wpasupplicant.v1.Supplicant.PacketCountPoll:input_type -> wpasupplicant.v1.Empty
+	8,  // 10: wpasupplicant.v1.Supplicant.SignalMonitor:input_type -> wpasupplicant.v1.SignalMonitorRequest
+	0,  // 11: wpasupplicant.v1.Supplicant.Scan:input_type -> wpasupplicant.v1.Empty
+	0,  // 12: wpasupplicant.v1.Supplicant.AbortScan:input_type -> wpasupplicant.v1.Empty
+	0,  // 13: wpasupplicant.v1.Supplicant.ScanResults:input_type -> wpasupplicant.v1.Empty
+	1,  // 14: wpasupplicant.v1.Supplicant.SetScanInterval:input_type -> wpasupplicant.v1.IntValue
+	11, // 15: wpasupplicant.v1.Supplicant.Autoscan:input_type -> wpasupplicant.v1.AutoscanRequest
+	1,  // 16: wpasupplicant.v1.Supplicant.FlushBSS:input_type -> wpasupplicant.v1.IntValue
+	1,  // 17: wpasupplicant.v1.Supplicant.SetBSSExpireAge:input_type -> wpasupplicant.v1.IntValue
+	1,  // 18: wpasupplicant.v1.Supplicant.SetBSSExpireCount:input_type -> wpasupplicant.v1.IntValue
+	0,  // 19: wpasupplicant.v1.Supplicant.ListNetworks:input_type -> wpasupplicant.v1.Empty
+	0,  // 20: wpasupplicant.v1.Supplicant.AddNetwork:input_type -> wpasupplicant.v1.Empty
+	14, // 21: wpasupplicant.v1.Supplicant.SetNetwork:input_type -> wpasupplicant.v1.SetNetworkRequest
+	15, // 22: wpasupplicant.v1.Supplicant.GetNetwork:input_type -> wpasupplicant.v1.GetNetworkRequest
+	18, // 23: wpasupplicant.v1.Supplicant.ApplyNetwork:input_type -> wpasupplicant.v1.ApplyNetworkRequest
+	4,  // 24: wpasupplicant.v1.Supplicant.ReadNetwork:input_type -> wpasupplicant.v1.NetworkID
+	4,  // 25: wpasupplicant.v1.Supplicant.EnableNetwork:input_type -> wpasupplicant.v1.NetworkID
+	0,  // 26: wpasupplicant.v1.Supplicant.EnableAllNetworks:input_type -> wpasupplicant.v1.Empty
+	4,  // 27: wpasupplicant.v1.Supplicant.SelectNetwork:input_type -> wpasupplicant.v1.NetworkID
+	4,  // 28: wpasupplicant.v1.Supplicant.DisableNetwork:input_type -> wpasupplicant.v1.NetworkID
+	4,  // 29: wpasupplicant.v1.Supplicant.RemoveNetwork:input_type -> wpasupplicant.v1.NetworkID
+	0,  // 30: wpasupplicant.v1.Supplicant.RemoveAllNetworks:input_type -> wpasupplicant.v1.Empty
+	19, // 31: wpasupplicant.v1.Supplicant.Respond:input_type -> wpasupplicant.v1.RespondRequest
+	20, // 32: wpasupplicant.v1.Supplicant.SetBlob:input_type -> wpasupplicant.v1.SetBlobRequest
+	1,  // 33: wpasupplicant.v1.Supplicant.SetSAEPWE:input_type -> wpasupplicant.v1.IntValue
+	0,  // 34: wpasupplicant.v1.Supplicant.SaveConfig:input_type -> wpasupplicant.v1.Empty
+	0,  // 35: wpasupplicant.v1.Supplicant.Reconfigure:input_type -> wpasupplicant.v1.Empty
+	0,  // 36: wpasupplicant.v1.Supplicant.Reassociate:input_type -> wpasupplicant.v1.Empty
+	0,  // 37: wpasupplicant.v1.Supplicant.Reconnect:input_type -> wpasupplicant.v1.Empty
+	0,  // 38: wpasupplicant.v1.Supplicant.Events:input_type -> wpasupplicant.v1.Empty
+	0,  // 39: wpasupplicant.v1.Supplicant.Ping:output_type -> wpasupplicant.v1.Empty
+	3,  // 40: wpasupplicant.v1.Supplicant.Command:output_type -> wpasupplicant.v1.CommandResponse
+	5,  // 41: wpasupplicant.v1.Supplicant.Status:output_type -> wpasupplicant.v1.StatusResponse
+	6,  // 42: wpasupplicant.v1.Supplicant.SignalPoll:output_type -> wpasupplicant.v1.SignalPollResponse
+	7,  // 43: wpasupplicant.v1.Supplicant.PacketCountPoll:output_type -> wpasupplicant.v1.PacketCountResponse
+	0,  // 44: wpasupplicant.v1.Supplicant.SignalMonitor:output_type -> wpasupplicant.v1.Empty
+	0,  // 45: wpasupplicant.v1.Supplicant.Scan:output_type -> wpasupplicant.v1.Empty
+	0,  // 46: wpasupplicant.v1.Supplicant.AbortScan:output_type -> wpasupplicant.v1.Empty
+	10, // 47: wpasupplicant.v1.Supplicant.ScanResults:output_type -> wpasupplicant.v1.ScanResultsResponse
+	0,  // 48: wpasupplicant.v1.Supplicant.SetScanInterval:output_type -> wpasupplicant.v1.Empty
+	0,  // 49: wpasupplicant.v1.Supplicant.Autoscan:output_type -> wpasupplicant.v1.Empty
+	0,  // 50: wpasupplicant.v1.Supplicant.FlushBSS:output_type -> wpasupplicant.v1.Empty
+	0,  // 51: wpasupplicant.v1.Supplicant.SetBSSExpireAge:output_type -> wpasupplicant.v1.Empty
+	0,  // 52: wpasupplicant.v1.Supplicant.SetBSSExpireCount:output_type -> wpasupplicant.v1.Empty
+	13, // 53: wpasupplicant.v1.Supplicant.ListNetworks:output_type -> wpasupplicant.v1.ListNetworksResponse
+	4,  // 54: wpasupplicant.v1.Supplicant.AddNetwork:output_type -> wpasupplicant.v1.NetworkID
+	0,  // 55: wpasupplicant.v1.Supplicant.SetNetwork:output_type -> wpasupplicant.v1.Empty
+	16, // 56: wpasupplicant.v1.Supplicant.GetNetwork:output_type -> wpasupplicant.v1.GetNetworkResponse
+	0,  // 57: wpasupplicant.v1.Supplicant.ApplyNetwork:output_type -> wpasupplicant.v1.Empty
+	17, // 58: wpasupplicant.v1.Supplicant.ReadNetwork:output_type -> wpasupplicant.v1.NetworkConfig
+	0,  // 59: wpasupplicant.v1.Supplicant.EnableNetwork:output_type -> wpasupplicant.v1.Empty
+	0,  // 60: wpasupplicant.v1.Supplicant.EnableAllNetworks:output_type -> wpasupplicant.v1.Empty
+	0,  // 61: wpasupplicant.v1.Supplicant.SelectNetwork:output_type -> wpasupplicant.v1.Empty
+	0,  // 62: wpasupplicant.v1.Supplicant.DisableNetwork:output_type -> wpasupplicant.v1.Empty
+	0,  // 63: wpasupplicant.v1.Supplicant.RemoveNetwork:output_type -> wpasupplicant.v1.Empty
+	0,  // 64: wpasupplicant.v1.Supplicant.RemoveAllNetworks:output_type -> wpasupplicant.v1.Empty
+	0,  // 65: wpasupplicant.v1.Supplicant.Respond:output_type -> wpasupplicant.v1.Empty
+	0,  // 66: wpasupplicant.v1.Supplicant.SetBlob:output_type -> wpasupplicant.v1.Empty
+	0,  // 67: wpasupplicant.v1.Supplicant.SetSAEPWE:output_type -> wpasupplicant.v1.Empty
+	0,  // 68: wpasupplicant.v1.Supplicant.SaveConfig:output_type -> wpasupplicant.v1.Empty
+	0,  // 69: wpasupplicant.v1.Supplicant.Reconfigure:output_type -> wpasupplicant.v1.Empty
+	0,  // 70: wpasupplicant.v1.Supplicant.Reassociate:output_type -> wpasupplicant.v1.Empty
+	0,  // 71: wpasupplicant.v1.Supplicant.Reconnect:output_type -> wpasupplicant.v1.Empty
+	21, // 72: wpasupplicant.v1.Supplicant.Events:output_type -> wpasupplicant.v1.Event
+	39, // [39:73] is the sub-list for method output_type
+	5,  // [5:39] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_wpasupplicant_proto_init() }
+func file_wpasupplicant_proto_init() {
+	if File_wpasupplicant_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_wpasupplicant_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*IntValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*CommandRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*CommandResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*NetworkID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*StatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*SignalPollResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*PacketCountResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*SignalMonitorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ScanResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ScanResultsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*AutoscanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*ConfiguredNetwork); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*ListNetworksResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*SetNetworkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*GetNetworkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*GetNetworkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*NetworkConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*ApplyNetworkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*RespondRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*SetBlobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wpasupplicant_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_wpasupplicant_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_wpasupplicant_proto_goTypes,
+		DependencyIndexes: file_wpasupplicant_proto_depIdxs,
+		MessageInfos:      file_wpasupplicant_proto_msgTypes,
+	}.Build()
+	File_wpasupplicant_proto = out.File
+	file_wpasupplicant_proto_rawDesc = nil
+	file_wpasupplicant_proto_goTypes = nil
+	file_wpasupplicant_proto_depIdxs = nil
+}
@@ -0,0 +1,1425 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: wpasupplicant.proto
+
+package wpapb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Supplicant_Ping_FullMethodName              = "/wpasupplicant.v1.Supplicant/Ping"
+	Supplicant_Command_FullMethodName           = "/wpasupplicant.v1.Supplicant/Command"
+	Supplicant_Status_FullMethodName            = "/wpasupplicant.v1.Supplicant/Status"
+	Supplicant_SignalPoll_FullMethodName        = "/wpasupplicant.v1.Supplicant/SignalPoll"
+	Supplicant_PacketCountPoll_FullMethodName   = "/wpasupplicant.v1.Supplicant/PacketCountPoll"
+	Supplicant_SignalMonitor_FullMethodName     = "/wpasupplicant.v1.Supplicant/SignalMonitor"
+	Supplicant_Scan_FullMethodName              = "/wpasupplicant.v1.Supplicant/Scan"
+	Supplicant_AbortScan_FullMethodName         = "/wpasupplicant.v1.Supplicant/AbortScan"
+	Supplicant_ScanResults_FullMethodName       = "/wpasupplicant.v1.Supplicant/ScanResults"
+	Supplicant_SetScanInterval_FullMethodName   = "/wpasupplicant.v1.Supplicant/SetScanInterval"
+	Supplicant_Autoscan_FullMethodName          = "/wpasupplicant.v1.Supplicant/Autoscan"
+	Supplicant_FlushBSS_FullMethodName          = "/wpasupplicant.v1.Supplicant/FlushBSS"
+	Supplicant_SetBSSExpireAge_FullMethodName   = "/wpasupplicant.v1.Supplicant/SetBSSExpireAge"
+	Supplicant_SetBSSExpireCount_FullMethodName = "/wpasupplicant.v1.Supplicant/SetBSSExpireCount"
+	Supplicant_ListNetworks_FullMethodName      = "/wpasupplicant.v1.Supplicant/ListNetworks"
+	Supplicant_AddNetwork_FullMethodName        = "/wpasupplicant.v1.Supplicant/AddNetwork"
+	Supplicant_SetNetwork_FullMethodName        = "/wpasupplicant.v1.Supplicant/SetNetwork"
+	Supplicant_GetNetwork_FullMethodName        = "/wpasupplicant.v1.Supplicant/GetNetwork"
+	Supplicant_ApplyNetwork_FullMethodName      = "/wpasupplicant.v1.Supplicant/ApplyNetwork"
+	Supplicant_ReadNetwork_FullMethodName       = "/wpasupplicant.v1.Supplicant/ReadNetwork"
+	Supplicant_EnableNetwork_FullMethodName     = "/wpasupplicant.v1.Supplicant/EnableNetwork"
+	Supplicant_EnableAllNetworks_FullMethodName = "/wpasupplicant.v1.Supplicant/EnableAllNetworks"
+	Supplicant_SelectNetwork_FullMethodName     = "/wpasupplicant.v1.Supplicant/SelectNetwork"
+	Supplicant_DisableNetwork_FullMethodName    = "/wpasupplicant.v1.Supplicant/DisableNetwork"
+	Supplicant_RemoveNetwork_FullMethodName     = "/wpasupplicant.v1.Supplicant/RemoveNetwork"
+	Supplicant_RemoveAllNetworks_FullMethodName = "/wpasupplicant.v1.Supplicant/RemoveAllNetworks"
+	Supplicant_Respond_FullMethodName           = "/wpasupplicant.v1.Supplicant/Respond"
+	Supplicant_SetBlob_FullMethodName           = "/wpasupplicant.v1.Supplicant/SetBlob"
+	Supplicant_SetSAEPWE_FullMethodName         = "/wpasupplicant.v1.Supplicant/SetSAEPWE"
+	Supplicant_SaveConfig_FullMethodName        = "/wpasupplicant.v1.Supplicant/SaveConfig"
+	Supplicant_Reconfigure_FullMethodName       = "/wpasupplicant.v1.Supplicant/Reconfigure"
+	Supplicant_Reassociate_FullMethodName       = "/wpasupplicant.v1.Supplicant/Reassociate"
+	Supplicant_Reconnect_FullMethodName         = "/wpasupplicant.v1.Supplicant/Reconnect"
+	Supplicant_Events_FullMethodName            = "/wpasupplicant.v1.Supplicant/Events"
+)
+
+// SupplicantClient is the client API for Supplicant service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Supplicant mirrors the wpasupplicant.Conn interface.  Each RPC
+// corresponds to the Conn method of the same name.
+//
+// If the server is configured with a token, calls must include it as a
+// bearer token in the authorization metadata.
+type SupplicantClient interface {
+	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandResponse, error)
+	Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error)
+	SignalPoll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SignalPollResponse, error)
+	PacketCountPoll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PacketCountResponse, error)
+	SignalMonitor(ctx context.Context, in *SignalMonitorRequest, opts ...grpc.CallOption) (*Empty, error)
+	Scan(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	AbortScan(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	ScanResults(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScanResultsResponse, error)
+	SetScanInterval(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error)
+	Autoscan(ctx context.Context, in *AutoscanRequest, opts ...grpc.CallOption) (*Empty, error)
+	FlushBSS(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error)
+	SetBSSExpireAge(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error)
+	SetBSSExpireCount(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error)
+	ListNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListNetworksResponse, error)
+	AddNetwork(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NetworkID, error)
+	SetNetwork(ctx context.Context, in *SetNetworkRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetNetwork(ctx context.Context, in *GetNetworkRequest, opts ...grpc.CallOption) (*GetNetworkResponse, error)
+	ApplyNetwork(ctx context.Context, in *ApplyNetworkRequest, opts ...grpc.CallOption) (*Empty, error)
+	ReadNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*NetworkConfig, error)
+	EnableNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*Empty, error)
+	EnableAllNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	SelectNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*Empty, error)
+	DisableNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*Empty, error)
+	RemoveNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*Empty, error)
+	RemoveAllNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Respond(ctx context.Context, in *RespondRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetBlob(ctx context.Context, in *SetBlobRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetSAEPWE(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error)
+	SaveConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Reconfigure(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Reassociate(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Reconnect(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Events streams the events from the EventQueue, starting when the
+	// call is made.  Events are dropped if the client doesn't keep up.
+	// Secrets are removed from events, as by WPAEvent.Redacted().
+	Events(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type supplicantClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSupplicantClient(cc grpc.ClientConnInterface) SupplicantClient {
+	return &supplicantClient{cc}
+}
+
+func (c *supplicantClient) Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, Supplicant_Command_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, Supplicant_Status_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SignalPoll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SignalPollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignalPollResponse)
+	err := c.cc.Invoke(ctx, Supplicant_SignalPoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) PacketCountPoll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PacketCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PacketCountResponse)
+	err := c.cc.Invoke(ctx, Supplicant_PacketCountPoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SignalMonitor(ctx context.Context, in *SignalMonitorRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_SignalMonitor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) Scan(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_Scan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) AbortScan(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_AbortScan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) ScanResults(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScanResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanResultsResponse)
+	err := c.cc.Invoke(ctx, Supplicant_ScanResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SetScanInterval(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_SetScanInterval_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) Autoscan(ctx context.Context, in *AutoscanRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_Autoscan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) FlushBSS(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_FlushBSS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SetBSSExpireAge(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_SetBSSExpireAge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SetBSSExpireCount(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_SetBSSExpireCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) ListNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListNetworksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNetworksResponse)
+	err := c.cc.Invoke(ctx, Supplicant_ListNetworks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) AddNetwork(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NetworkID, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetworkID)
+	err := c.cc.Invoke(ctx, Supplicant_AddNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SetNetwork(ctx context.Context, in *SetNetworkRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_SetNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) GetNetwork(ctx context.Context, in *GetNetworkRequest, opts ...grpc.CallOption) (*GetNetworkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNetworkResponse)
+	err := c.cc.Invoke(ctx, Supplicant_GetNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) ApplyNetwork(ctx context.Context, in *ApplyNetworkRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_ApplyNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) ReadNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*NetworkConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetworkConfig)
+	err := c.cc.Invoke(ctx, Supplicant_ReadNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) EnableNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_EnableNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) EnableAllNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_EnableAllNetworks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SelectNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_SelectNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) DisableNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_DisableNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) RemoveNetwork(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_RemoveNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) RemoveAllNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_RemoveAllNetworks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) Respond(ctx context.Context, in *RespondRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_Respond_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SetBlob(ctx context.Context, in *SetBlobRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_SetBlob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SetSAEPWE(ctx context.Context, in *IntValue, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_SetSAEPWE_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) SaveConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_SaveConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) Reconfigure(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_Reconfigure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) Reassociate(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_Reassociate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) Reconnect(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Supplicant_Reconnect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplicantClient) Events(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Supplicant_ServiceDesc.Streams[0], Supplicant_Events_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Empty, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Supplicant_EventsClient = grpc.ServerStreamingClient[Event]
+
+// SupplicantServer is the server API for Supplicant service.
+// All implementations must embed UnimplementedSupplicantServer
+// for forward compatibility.
+//
+// Supplicant mirrors the wpasupplicant.Conn interface.  Each RPC
+// corresponds to the Conn method of the same name.
+//
+// If the server is configured with a token, calls must include it as a
+// bearer token in the authorization metadata.
+type SupplicantServer interface {
+	Ping(context.Context, *Empty) (*Empty, error)
+	Command(context.Context, *CommandRequest) (*CommandResponse, error)
+	Status(context.Context, *Empty) (*StatusResponse, error)
+	SignalPoll(context.Context, *Empty) (*SignalPollResponse, error)
+	PacketCountPoll(context.Context, *Empty) (*PacketCountResponse, error)
+	SignalMonitor(context.Context, *SignalMonitorRequest) (*Empty, error)
+	Scan(context.Context, *Empty) (*Empty, error)
+	AbortScan(context.Context, *Empty) (*Empty, error)
+	ScanResults(context.Context, *Empty) (*ScanResultsResponse, error)
+	SetScanInterval(context.Context, *IntValue) (*Empty, error)
+	Autoscan(context.Context, *AutoscanRequest) (*Empty, error)
+	FlushBSS(context.Context, *IntValue) (*Empty, error)
+	SetBSSExpireAge(context.Context, *IntValue) (*Empty, error)
+	SetBSSExpireCount(context.Context, *IntValue) (*Empty, error)
+	ListNetworks(context.Context, *Empty) (*ListNetworksResponse, error)
+	AddNetwork(context.Context, *Empty) (*NetworkID, error)
+	SetNetwork(context.Context, *SetNetworkRequest) (*Empty, error)
+	GetNetwork(context.Context, *GetNetworkRequest) (*GetNetworkResponse, error)
+	ApplyNetwork(context.Context, *ApplyNetworkRequest) (*Empty, error)
+	ReadNetwork(context.Context, *NetworkID) (*NetworkConfig, error)
+	EnableNetwork(context.Context, *NetworkID) (*Empty, error)
+	EnableAllNetworks(context.Context, *Empty) (*Empty, error)
+	SelectNetwork(context.Context, *NetworkID) (*Empty, error)
+	DisableNetwork(context.Context, *NetworkID) (*Empty, error)
+	RemoveNetwork(context.Context, *NetworkID) (*Empty, error)
+	RemoveAllNetworks(context.Context, *Empty) (*Empty, error)
+	Respond(context.Context, *RespondRequest) (*Empty, error)
+	SetBlob(context.Context, *SetBlobRequest) (*Empty, error)
+	SetSAEPWE(context.Context, *IntValue) (*Empty, error)
+	SaveConfig(context.Context, *Empty) (*Empty, error)
+	Reconfigure(context.Context, *Empty) (*Empty, error)
+	Reassociate(context.Context, *Empty) (*Empty, error)
+	Reconnect(context.Context, *Empty) (*Empty, error)
+	// Events streams the events from the EventQueue, starting when the
+	// call is made.  Events are dropped if the client doesn't keep up.
+	// Secrets are removed from events, as by WPAEvent.Redacted().
+	Events(*Empty, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedSupplicantServer()
+}
+
+// UnimplementedSupplicantServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSupplicantServer struct{}
+
+func (UnimplementedSupplicantServer) Ping(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedSupplicantServer) Command(context.Context, *CommandRequest) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Command not implemented")
+}
+func (UnimplementedSupplicantServer) Status(context.Context, *Empty) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedSupplicantServer) SignalPoll(context.Context, *Empty) (*SignalPollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignalPoll not implemented")
+}
+func (UnimplementedSupplicantServer) PacketCountPoll(context.Context, *Empty) (*PacketCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PacketCountPoll not implemented")
+}
+func (UnimplementedSupplicantServer) SignalMonitor(context.Context, *SignalMonitorRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignalMonitor not implemented")
+}
+func (UnimplementedSupplicantServer) Scan(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
+func (UnimplementedSupplicantServer) AbortScan(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortScan not implemented")
+}
+func (UnimplementedSupplicantServer) ScanResults(context.Context, *Empty) (*ScanResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScanResults not implemented")
+}
+func (UnimplementedSupplicantServer) SetScanInterval(context.Context, *IntValue) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetScanInterval not implemented")
+}
+func (UnimplementedSupplicantServer) Autoscan(context.Context, *AutoscanRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Autoscan not implemented")
+}
+func (UnimplementedSupplicantServer) FlushBSS(context.Context, *IntValue) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushBSS not implemented")
+}
+func (UnimplementedSupplicantServer) SetBSSExpireAge(context.Context, *IntValue) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBSSExpireAge not implemented")
+}
+func (UnimplementedSupplicantServer) SetBSSExpireCount(context.Context, *IntValue) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBSSExpireCount not implemented")
+}
+func (UnimplementedSupplicantServer) ListNetworks(context.Context, *Empty) (*ListNetworksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNetworks not implemented")
+}
+func (UnimplementedSupplicantServer) AddNetwork(context.Context, *Empty) (*NetworkID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddNetwork not implemented")
+}
+func (UnimplementedSupplicantServer) SetNetwork(context.Context, *SetNetworkRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetNetwork not implemented")
+}
+func (UnimplementedSupplicantServer) GetNetwork(context.Context, *GetNetworkRequest) (*GetNetworkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetwork not implemented")
+}
+func (UnimplementedSupplicantServer) ApplyNetwork(context.Context, *ApplyNetworkRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyNetwork not implemented")
+}
+func (UnimplementedSupplicantServer) ReadNetwork(context.Context, *NetworkID) (*NetworkConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadNetwork not implemented")
+}
+func (UnimplementedSupplicantServer) EnableNetwork(context.Context, *NetworkID) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableNetwork not implemented")
+}
+func (UnimplementedSupplicantServer) EnableAllNetworks(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableAllNetworks not implemented")
+}
+func (UnimplementedSupplicantServer) SelectNetwork(context.Context, *NetworkID) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectNetwork not implemented")
+}
+func (UnimplementedSupplicantServer) DisableNetwork(context.Context, *NetworkID) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableNetwork not implemented")
+}
+func (UnimplementedSupplicantServer) RemoveNetwork(context.Context, *NetworkID) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveNetwork not implemented")
+}
+func (UnimplementedSupplicantServer) RemoveAllNetworks(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAllNetworks not implemented")
+}
+func (UnimplementedSupplicantServer) Respond(context.Context, *RespondRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Respond not implemented")
+}
+func (UnimplementedSupplicantServer) SetBlob(context.Context, *SetBlobRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBlob not implemented")
+}
+func (UnimplementedSupplicantServer) SetSAEPWE(context.Context, *IntValue) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSAEPWE not implemented")
+}
+func (UnimplementedSupplicantServer) SaveConfig(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveConfig not implemented")
+}
+func (UnimplementedSupplicantServer) Reconfigure(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconfigure not implemented")
+}
+func (UnimplementedSupplicantServer) Reassociate(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reassociate not implemented")
+}
+func (UnimplementedSupplicantServer) Reconnect(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconnect not implemented")
+}
+func (UnimplementedSupplicantServer) Events(*Empty, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+func (UnimplementedSupplicantServer) mustEmbedUnimplementedSupplicantServer() {}
+func (UnimplementedSupplicantServer) testEmbeddedByValue()                    {}
+
+// UnsafeSupplicantServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SupplicantServer will
+// result in compilation errors.
+type UnsafeSupplicantServer interface {
+	mustEmbedUnimplementedSupplicantServer()
+}
+
+func RegisterSupplicantServer(s grpc.ServiceRegistrar, srv SupplicantServer) {
+	// If the following call pancis, it indicates UnimplementedSupplicantServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Supplicant_ServiceDesc, srv)
+}
+
+func _Supplicant_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).Ping(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_Command_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).Command(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_Command_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).Command(ctx, req.(*CommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).Status(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SignalPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SignalPoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SignalPoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SignalPoll(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_PacketCountPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).PacketCountPoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_PacketCountPoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).PacketCountPoll(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SignalMonitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalMonitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SignalMonitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SignalMonitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SignalMonitor(ctx, req.(*SignalMonitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_Scan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).Scan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_Scan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).Scan(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_AbortScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).AbortScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_AbortScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).AbortScan(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_ScanResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).ScanResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_ScanResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).ScanResults(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SetScanInterval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SetScanInterval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SetScanInterval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SetScanInterval(ctx, req.(*IntValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_Autoscan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutoscanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).Autoscan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_Autoscan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).Autoscan(ctx, req.(*AutoscanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_FlushBSS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).FlushBSS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_FlushBSS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).FlushBSS(ctx, req.(*IntValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SetBSSExpireAge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SetBSSExpireAge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SetBSSExpireAge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SetBSSExpireAge(ctx, req.(*IntValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SetBSSExpireCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SetBSSExpireCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SetBSSExpireCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SetBSSExpireCount(ctx, req.(*IntValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_ListNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).ListNetworks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_ListNetworks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).ListNetworks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_AddNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).AddNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_AddNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).AddNetwork(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SetNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SetNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SetNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SetNetwork(ctx, req.(*SetNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_GetNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).GetNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_GetNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).GetNetwork(ctx, req.(*GetNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_ApplyNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).ApplyNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_ApplyNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).ApplyNetwork(ctx, req.(*ApplyNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_ReadNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).ReadNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_ReadNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).ReadNetwork(ctx, req.(*NetworkID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_EnableNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).EnableNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_EnableNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).EnableNetwork(ctx, req.(*NetworkID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_EnableAllNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).EnableAllNetworks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_EnableAllNetworks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).EnableAllNetworks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SelectNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SelectNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SelectNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SelectNetwork(ctx, req.(*NetworkID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_DisableNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).DisableNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_DisableNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).DisableNetwork(ctx, req.(*NetworkID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_RemoveNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).RemoveNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_RemoveNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).RemoveNetwork(ctx, req.(*NetworkID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_RemoveAllNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).RemoveAllNetworks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_RemoveAllNetworks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).RemoveAllNetworks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_Respond_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).Respond(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_Respond_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).Respond(ctx, req.(*RespondRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SetBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SetBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SetBlob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SetBlob(ctx, req.(*SetBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SetSAEPWE_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SetSAEPWE(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SetSAEPWE_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SetSAEPWE(ctx, req.(*IntValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_SaveConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).SaveConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_SaveConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).SaveConfig(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_Reconfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).Reconfigure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_Reconfigure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).Reconfigure(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_Reassociate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).Reassociate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_Reassociate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).Reassociate(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_Reconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplicantServer).Reconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Supplicant_Reconnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplicantServer).Reconnect(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Supplicant_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SupplicantServer).Events(m, &grpc.GenericServerStream[Empty, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Supplicant_EventsServer = grpc.ServerStreamingServer[Event]
+
+// Supplicant_ServiceDesc is the grpc.ServiceDesc for Supplicant service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Supplicant_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wpasupplicant.v1.Supplicant",
+	HandlerType: (*SupplicantServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _Supplicant_Ping_Handler,
+		},
+		{
+			MethodName: "Command",
+			Handler:    _Supplicant_Command_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Supplicant_Status_Handler,
+		},
+		{
+			MethodName: "SignalPoll",
+			Handler:    _Supplicant_SignalPoll_Handler,
+		},
+		{
+			MethodName: "PacketCountPoll",
+			Handler:    _Supplicant_PacketCountPoll_Handler,
+		},
+		{
+			MethodName: "SignalMonitor",
+			Handler:    _Supplicant_SignalMonitor_Handler,
+		},
+		{
+			MethodName: "Scan",
+			Handler:    _Supplicant_Scan_Handler,
+		},
+		{
+			MethodName: "AbortScan",
+			Handler:    _Supplicant_AbortScan_Handler,
+		},
+		{
+			MethodName: "ScanResults",
+			Handler:    _Supplicant_ScanResults_Handler,
+		},
+		{
+			MethodName: "SetScanInterval",
+			Handler:    _Supplicant_SetScanInterval_Handler,
+		},
+		{
+			MethodName: "Autoscan",
+			Handler:    _Supplicant_Autoscan_Handler,
+		},
+		{
+			MethodName: "FlushBSS",
+			Handler:    _Supplicant_FlushBSS_Handler,
+		},
+		{
+			MethodName: "SetBSSExpireAge",
+			Handler:    _Supplicant_SetBSSExpireAge_Handler,
+		},
+		{
+			MethodName: "SetBSSExpireCount",
+			Handler:    _Supplicant_SetBSSExpireCount_Handler,
+		},
+		{
+			MethodName: "ListNetworks",
+			Handler:    _Supplicant_ListNetworks_Handler,
+		},
+		{
+			MethodName: "AddNetwork",
+			Handler:    _Supplicant_AddNetwork_Handler,
+		},
+		{
+			MethodName: "SetNetwork",
+			Handler:    _Supplicant_SetNetwork_Handler,
+		},
+		{
+			MethodName: "GetNetwork",
+			Handler:    _Supplicant_GetNetwork_Handler,
+		},
+		{
+			MethodName: "ApplyNetwork",
+			Handler:    _Supplicant_ApplyNetwork_Handler,
+		},
+		{
+			MethodName: "ReadNetwork",
+			Handler:    _Supplicant_ReadNetwork_Handler,
+		},
+		{
+			MethodName: "EnableNetwork",
+			Handler:    _Supplicant_EnableNetwork_Handler,
+		},
+		{
+			MethodName: "EnableAllNetworks",
+			Handler:    _Supplicant_EnableAllNetworks_Handler,
+		},
+		{
+			MethodName: "SelectNetwork",
+			Handler:    _Supplicant_SelectNetwork_Handler,
+		},
+		{
+			MethodName: "DisableNetwork",
+			Handler:    _Supplicant_DisableNetwork_Handler,
+		},
+		{
+			MethodName: "RemoveNetwork",
+			Handler:    _Supplicant_RemoveNetwork_Handler,
+		},
+		{
+			MethodName: "RemoveAllNetworks",
+			Handler:    _Supplicant_RemoveAllNetworks_Handler,
+		},
+		{
+			MethodName: "Respond",
+			Handler:    _Supplicant_Respond_Handler,
+		},
+		{
+			MethodName: "SetBlob",
+			Handler:    _Supplicant_SetBlob_Handler,
+		},
+		{
+			MethodName: "SetSAEPWE",
+			Handler:    _Supplicant_SetSAEPWE_Handler,
+		},
+		{
+			MethodName: "SaveConfig",
+			Handler:    _Supplicant_SaveConfig_Handler,
+		},
+		{
+			MethodName: "Reconfigure",
+			Handler:    _Supplicant_Reconfigure_Handler,
+		},
+		{
+			MethodName: "Reassociate",
+			Handler:    _Supplicant_Reassociate_Handler,
+		},
+		{
+			MethodName: "Reconnect",
+			Handler:    _Supplicant_Reconnect_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Events",
+			Handler:       _Supplicant_Events_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "wpasupplicant.proto",
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+)
+
+// ScanAndWait triggers a new scan, waits for it to complete, and returns the
+// results.  Use the context to limit how long to wait.
+//
+// ScanAndWait consumes the Conn's EventQueue while it runs, so callers
+// should not be reading events from it at the same time.
+func ScanAndWait(ctx context.Context, c Conn) ([]ScanResult, []error) {
+	events := newEventPump(c)
+	defer events.stop()
+
+	if err := c.Scan(); err != nil {
+		return nil, []error{err}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, []error{ctx.Err()}
+
+		case ev := <-events.C:
+			switch ev.Event {
+			case "SCAN-RESULTS":
+				return c.ScanResults()
+			case "SCAN-FAILED":
+				return nil, []error{&ParseError{Line: ev.Line}}
+			}
+		}
+	}
+}
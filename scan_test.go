@@ -0,0 +1,65 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScanAndWait(t *testing.T) {
+	var fs *fakeServer
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch cmd {
+		case "SCAN":
+			go func() {
+				fs.event("CTRL-EVENT-SCAN-STARTED ")
+				fs.event("CTRL-EVENT-SCAN-RESULTS ")
+			}()
+		case "SCAN_RESULTS":
+			return "bssid / frequency / signal level / flags / ssid\n" +
+				"8a:15:14:8a:46:51\t2412\t-58\t[ESS]\thome\n"
+		}
+		return ""
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, errs := ScanAndWait(ctx, conn)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if len(res) != 1 || res[0].SSID() != "home" {
+		t.Errorf("wrong scan results (got %v)", res)
+	}
+
+	fs.expectCommands("SCAN", "SCAN_RESULTS")
+}
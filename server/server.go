@@ -0,0 +1,509 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package server exposes a wpasupplicant.Conn over HTTP, for processes
+// which can't access the wpa_supplicant control socket themselves.
+//
+// Requests and responses are JSON.  Network configurations use the field
+// names of wpasupplicant.NetworkConfig, and secrets such as the PSK are
+// never included in responses.  SSIDs in responses which aren't printable
+// UTF-8 are escaped, as by wpasupplicant.SSID.String().  Fields omitted
+// from a PUT request, including secrets, are left unchanged.  The API is:
+//
+//	GET    /status                  interface status
+//	GET    /scan                    current scan results
+//	POST   /scan[?wait=true]        trigger a scan, optionally waiting for the results
+//	GET    /networks                configured networks
+//	POST   /networks                add a network (NetworkConfig), returning its ID
+//	GET    /networks/{id}           read a network's configuration
+//	PUT    /networks/{id}           update a network's configuration
+//	DELETE /networks/{id}           remove a network
+//	POST   /networks/{id}/select    select a network
+//	POST   /networks/{id}/enable    enable a network
+//	POST   /networks/{id}/disable   disable a network
+//	POST   /connect[?timeout=30s]   add a network (NetworkConfig) and wait for it to connect
+//	GET    /events                  stream events as Server-Sent Events
+//
+// Events are only streamed as Server-Sent Events.  WebSocket isn't
+// supported, since the standard library doesn't implement it and the API
+// never needs to receive messages on the stream.  Secrets carried by
+// events, such as a P2P group's passphrase or a received DPP or WPS
+// credential, are removed as by wpasupplicant.WPAEvent.Redacted().
+//
+// If a token is configured, requests must include it as a bearer token in
+// the Authorization header, or (since the browser EventSource API can't set
+// headers) in the access_token query parameter.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pifke.org/wpasupplicant"
+	"pifke.org/wpasupplicant/wpajson"
+)
+
+// Server serves the API for a wpasupplicant.Conn.  It implements
+// http.Handler.
+type Server struct {
+	conn  wpasupplicant.Conn
+	token string
+	mux   *http.ServeMux
+
+	events *wpasupplicant.EventBroadcaster
+}
+
+// New returns a Server for the specified Conn.  If token is empty, requests
+// are not authenticated.
+func New(c wpasupplicant.Conn, token string) *Server {
+	s := &Server{
+		conn:   c,
+		token:  token,
+		mux:    http.NewServeMux(),
+		events: wpasupplicant.NewEventBroadcaster(c),
+	}
+
+	s.mux.HandleFunc("/status", s.handleStatus)
+	s.mux.HandleFunc("/scan", s.handleScan)
+	s.mux.HandleFunc("/networks", s.handleNetworks)
+	s.mux.HandleFunc("/networks/", s.handleNetwork)
+	s.mux.HandleFunc("/connect", s.handleConnect)
+	s.mux.HandleFunc("/events", s.handleEvents)
+
+	return s
+}
+
+// Run distributes events from the Conn's EventQueue to the event streams
+// and in-progress requests, until the context is done.  It must be running
+// for the server to work.  Callers should not be reading events from the
+// EventQueue at the same time.
+func (s *Server) Run(ctx context.Context) error {
+	return s.events.Run(ctx)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="wpa_supplicant"`)
+		writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized checks the request's token.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+
+	token := r.URL.Query().Get("access_token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// allowMethods writes an error response, and returns false, if the request
+// doesn't use one of the specified methods.
+func allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, m := range methods {
+		if r.Method == m {
+			return true
+		}
+	}
+
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	return false
+}
+
+// writeJSON writes a successful response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// errorStatus is the HTTP status for an error returned by the Conn.
+// Configuration errors are the client's fault; anything else is
+// wpa_supplicant's.
+func errorStatus(err error) int {
+	switch err.(type) {
+	case *wpasupplicant.ConfigError:
+		return http.StatusBadRequest
+	}
+
+	switch err {
+	case wpasupplicant.ErrPassphraseLength, wpasupplicant.ErrPassphraseCharset,
+		wpasupplicant.ErrEmptySSID, wpasupplicant.ErrEmptySAEPassword:
+		return http.StatusBadRequest
+	case wpasupplicant.ErrNetworkNotFound:
+		return http.StatusNotFound
+	}
+	return http.StatusBadGateway
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, "GET") {
+		return
+	}
+
+	st, err := s.conn.Status()
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, wpajson.NewStatus(st))
+}
+
+func writeScanResults(w http.ResponseWriter, results []wpasupplicant.ScanResult) {
+	bss := make([]wpajson.BSS, 0, len(results))
+	for _, r := range results {
+		bss = append(bss, wpajson.NewBSS(r))
+	}
+	writeJSON(w, http.StatusOK, bss)
+}
+
+func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, "GET", "POST") {
+		return
+	}
+
+	if r.Method == "GET" {
+		results, errs := s.conn.ScanResults()
+		if len(errs) > 0 {
+			writeError(w, errorStatus(errs[0]), errs[0])
+			return
+		}
+		writeScanResults(w, results)
+		return
+	}
+
+	if wait, _ := strconv.ParseBool(r.URL.Query().Get("wait")); !wait {
+		if err := s.conn.Scan(); err != nil {
+			writeError(w, errorStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	ctx, cancel, err := timeoutContext(r, 10*time.Second)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	defer cancel()
+
+	sub := s.events.Subscribe()
+	defer s.events.Unsubscribe(sub)
+
+	results, errs := wpasupplicant.ScanAndWait(ctx, sub)
+	if len(errs) > 0 {
+		status := errorStatus(errs[0])
+		if errs[0] == context.DeadlineExceeded {
+			status = http.StatusGatewayTimeout
+		}
+		writeError(w, status, errs[0])
+		return
+	}
+	writeScanResults(w, results)
+}
+
+// timeoutContext returns a context for the request, with the timeout from
+// the timeout query parameter, or the default.
+func timeoutContext(r *http.Request, def time.Duration) (context.Context, context.CancelFunc, error) {
+	timeout := def
+	if t := r.URL.Query().Get("timeout"); t != "" {
+		var err error
+		if timeout, err = time.ParseDuration(t); err != nil {
+			return nil, nil, fmt.Errorf("invalid timeout %q", t)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	return ctx, cancel, nil
+}
+
+func (s *Server) handleNetworks(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, "GET", "POST") {
+		return
+	}
+	if r.Method == "POST" {
+		s.handleAddNetwork(w, r)
+		return
+	}
+
+	networks, err := s.conn.ListNetworks()
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	res := make([]wpajson.Network, 0, len(networks))
+	for _, n := range networks {
+		res = append(res, wpajson.NewNetwork(n))
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// readConfig decodes a NetworkConfig from the request body.
+func readConfig(r *http.Request) (wpasupplicant.NetworkConfig, error) {
+	var cfg wpasupplicant.NetworkConfig
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("invalid network configuration: %s", err)
+	}
+	return cfg, nil
+}
+
+func (s *Server) handleAddNetwork(w http.ResponseWriter, r *http.Request) {
+	cfg, err := readConfig(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err = cfg.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := s.conn.AddNetwork()
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	if err = s.conn.ApplyNetwork(id, cfg); err != nil {
+		s.conn.RemoveNetwork(id)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]int{"id": id})
+}
+
+// handleNetwork handles the /networks/{id} and /networks/{id}/{action}
+// endpoints.
+func (s *Server) handleNetwork(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/networks/"), "/")
+	if len(path) > 2 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s not found", r.URL.Path))
+		return
+	}
+
+	id, err := strconv.Atoi(path[0])
+	if err != nil || id < 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid network id %q", path[0]))
+		return
+	}
+
+	if len(path) == 2 {
+		s.handleNetworkAction(w, r, id, path[1])
+		return
+	}
+
+	if !allowMethods(w, r, "GET", "PUT", "DELETE") {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		cfg, err := s.conn.ReadNetwork(id)
+		if err != nil {
+			writeError(w, errorStatus(err), err)
+			return
+		}
+		cfg = cfg.Redacted()
+		cfg.SSID = wpasupplicant.SSID(cfg.SSID).String()
+		writeJSON(w, http.StatusOK, cfg)
+		return
+
+	case "PUT":
+		var cfg wpasupplicant.NetworkConfig
+		if cfg, err = readConfig(r); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		err = s.conn.ApplyNetwork(id, cfg)
+
+	case "DELETE":
+		err = s.conn.RemoveNetwork(id)
+	}
+
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleNetworkAction(w http.ResponseWriter, r *http.Request, id int, action string) {
+	if !allowMethods(w, r, "POST") {
+		return
+	}
+
+	var err error
+	switch action {
+	case "select":
+		err = s.conn.SelectNetwork(id)
+	case "enable":
+		err = s.conn.EnableNetwork(id)
+	case "disable":
+		err = s.conn.DisableNetwork(id)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s not found", r.URL.Path))
+		return
+	}
+
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleConnect(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, "POST") {
+		return
+	}
+
+	cfg, err := readConfig(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	ctx, cancel, err := timeoutContext(r, 30*time.Second)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	defer cancel()
+
+	sub := s.events.Subscribe()
+	defer s.events.Unsubscribe(sub)
+
+	res, err := wpasupplicant.Connect(ctx, sub, cfg,
+		&wpasupplicant.ConnectOptions{RemoveOnFailure: true})
+	if cerr, ok := err.(*wpasupplicant.ConnectError); ok {
+		status := http.StatusBadGateway
+		if cerr.Reason == wpasupplicant.ConnectTimeout {
+			status = http.StatusGatewayTimeout
+		}
+		writeJSON(w, status, wpajson.ConnectResult{
+			ID:         cerr.NetworkID,
+			Reason:     cerr.Reason.String(),
+			StatusCode: cerr.StatusCode,
+		})
+		return
+	} else if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, wpajson.ConnectResult{
+		Connected: true,
+		ID:        res.NetworkID,
+		BSSID:     res.BSSID.String(),
+		Frequency: res.Frequency,
+	})
+}
+
+// Event is the JSON form of wpasupplicant.WPAEvent.
+type Event struct {
+	Event     string            `json:"event"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+	Line      string            `json:"line"`
+}
+
+// keepaliveInterval is how often a comment is sent on idle event streams,
+// so proxies don't time them out.
+var keepaliveInterval = 30 * time.Second
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, "GET") {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+
+	sub := s.events.Subscribe()
+	defer s.events.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	t := time.NewTicker(keepaliveInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case ev := <-sub.EventQueue():
+			ev = ev.Redacted()
+			data, err := json.Marshal(Event{
+				Event:     ev.Event,
+				Arguments: ev.Arguments,
+				Line:      ev.Line,
+			})
+			if err != nil {
+				return
+			}
+			if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Event, data); err != nil {
+				return
+			}
+		case <-t.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
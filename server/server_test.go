@@ -0,0 +1,356 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"pifke.org/wpasupplicant"
+	"pifke.org/wpasupplicant/wpajson"
+)
+
+// fakeConn implements the parts of wpasupplicant.Conn used by Server.
+type fakeConn struct {
+	wpasupplicant.Conn
+	events chan wpasupplicant.WPAEvent
+
+	mu       sync.Mutex
+	networks map[int]wpasupplicant.NetworkConfig
+	nextID   int
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		events:   make(chan wpasupplicant.WPAEvent),
+		networks: make(map[int]wpasupplicant.NetworkConfig),
+	}
+}
+
+func (c *fakeConn) EventQueue() chan wpasupplicant.WPAEvent { return c.events }
+
+type fakeStatus struct {
+	wpasupplicant.StatusResult
+}
+
+func (fakeStatus) WPAState() string        { return "COMPLETED" }
+func (fakeStatus) KeyMgmt() string         { return "WPA2-PSK" }
+func (fakeStatus) SSID() string            { return "home" }
+func (fakeStatus) BSSID() net.HardwareAddr { return net.HardwareAddr{2, 0, 0, 0, 1, 0} }
+func (fakeStatus) Frequency() int          { return 2412 }
+func (fakeStatus) IPAddr() string          { return "192.0.2.10" }
+func (fakeStatus) Address() string         { return "02:00:00:00:00:01" }
+
+func (c *fakeConn) Status() (wpasupplicant.StatusResult, error) {
+	return fakeStatus{}, nil
+}
+
+func (c *fakeConn) AddNetwork() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.nextID
+	c.nextID++
+	c.networks[id] = wpasupplicant.NetworkConfig{}
+	return id, nil
+}
+
+func (c *fakeConn) ApplyNetwork(id int, cfg wpasupplicant.NetworkConfig) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.networks[id] = cfg
+	return nil
+}
+
+func (c *fakeConn) ReadNetwork(id int) (wpasupplicant.NetworkConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfg, ok := c.networks[id]
+	if !ok {
+		return cfg, wpasupplicant.ErrNetworkNotFound
+	}
+	return cfg, nil
+}
+
+func (c *fakeConn) RemoveNetwork(id int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.networks, id)
+	return nil
+}
+
+func (c *fakeConn) ListNetworks() ([]wpasupplicant.ConfiguredNetwork, error) {
+	return nil, nil
+}
+
+func (c *fakeConn) SelectNetwork(id int) error {
+	go func() {
+		c.events <- wpasupplicant.WPAEvent{
+			Event: "CONNECTED",
+			Line:  "CTRL-EVENT-CONNECTED - Connection to 02:00:00:00:01:00 completed [id=0 id_str=]",
+		}
+	}()
+	return nil
+}
+
+// newTestServer returns a running Server for a fakeConn.
+func newTestServer(t *testing.T, token string) (*fakeConn, *httptest.Server) {
+	c := newFakeConn()
+	s := New(c, token)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.Run(ctx)
+
+	ts := httptest.NewServer(s)
+	t.Cleanup(func() {
+		ts.Close()
+		cancel()
+	})
+	return c, ts
+}
+
+func do(t *testing.T, method, url, token, body string) *http.Response {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestAuth(t *testing.T) {
+	_, ts := newTestServer(t, "s3cret")
+
+	for _, tc := range []struct {
+		token, query string
+		status       int
+	}{
+		{"", "", http.StatusUnauthorized},
+		{"wrong", "", http.StatusUnauthorized},
+		{"s3cret", "", http.StatusOK},
+		{"", "?access_token=s3cret", http.StatusOK},
+	} {
+		resp := do(t, "GET", ts.URL+"/status"+tc.query, tc.token, "")
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("token %q, query %q: got status %d, expected %d", tc.token, tc.query, resp.StatusCode, tc.status)
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	_, ts := newTestServer(t, "")
+
+	resp := do(t, "GET", ts.URL+"/status", "", "")
+	defer resp.Body.Close()
+
+	var st wpajson.Status
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+		t.Fatal(err)
+	}
+	expect := wpajson.Status{
+		WPAState:  "COMPLETED",
+		SSID:      "home",
+		BSSID:     "02:00:00:00:01:00",
+		Frequency: 2412,
+		Channel:   1,
+		KeyMgmt:   "WPA2-PSK",
+		IPAddr:    "192.0.2.10",
+		Address:   "02:00:00:00:00:01",
+	}
+	if st != expect {
+		t.Errorf("got %+v, expected %+v", st, expect)
+	}
+}
+
+func TestNetworks(t *testing.T) {
+	c, ts := newTestServer(t, "")
+
+	resp := do(t, "POST", ts.URL+"/networks", "", `{"SSID": "home", "PSK": "correct horse", "Priority": 5}`)
+	var added map[string]int
+	json.NewDecoder(resp.Body).Decode(&added)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || added["id"] != 0 {
+		t.Fatalf("add network: got status %d, response %v", resp.StatusCode, added)
+	}
+	if c.networks[0].PSK != "correct horse" {
+		t.Errorf("PSK not applied: %+v", c.networks[0])
+	}
+
+	// Secrets are redacted.
+	resp = do(t, "GET", ts.URL+"/networks/0", "", "")
+	var cfg map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&cfg)
+	resp.Body.Close()
+	if cfg["SSID"] != "home" || cfg["Priority"] != 5.0 || cfg["PSK"] != "" {
+		t.Errorf("read network: got %v", cfg)
+	}
+
+	for _, tc := range []struct {
+		method, path, body string
+		status             int
+	}{
+		{"POST", "/networks", `{"SSID": "home", "PSK": "short"}`, http.StatusBadRequest},
+		{"POST", "/networks", `{"NoSuchField": 1}`, http.StatusBadRequest},
+		{"GET", "/networks/x", "", http.StatusBadRequest},
+		{"GET", "/networks/999", "", http.StatusNotFound},
+		{"POST", "/networks/0/frobnicate", "", http.StatusNotFound},
+		{"DELETE", "/networks/0", "", http.StatusNoContent},
+	} {
+		resp = do(t, tc.method, ts.URL+tc.path, "", tc.body)
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s %s: got status %d, expected %d", tc.method, tc.path, resp.StatusCode, tc.status)
+		}
+	}
+	if len(c.networks) != 0 {
+		t.Errorf("networks not removed: %v", c.networks)
+	}
+}
+
+func TestReadNetworkSSID(t *testing.T) {
+	c, ts := newTestServer(t, "")
+	c.networks[0] = wpasupplicant.NetworkConfig{SSID: "caf\xe9"}
+
+	resp := do(t, "GET", ts.URL+"/networks/0", "", "")
+	defer resp.Body.Close()
+
+	var cfg wpasupplicant.NetworkConfig
+	if err := json.NewDecoder(resp.Body).Decode(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SSID != `caf\xe9` {
+		t.Errorf("got SSID %q", cfg.SSID)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	_, ts := newTestServer(t, "")
+
+	resp := do(t, "POST", ts.URL+"/connect?timeout=5s", "", `{"SSID": "home", "PSK": "correct horse"}`)
+	defer resp.Body.Close()
+
+	var res wpajson.ConnectResult
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	expect := wpajson.ConnectResult{
+		Connected: true,
+		BSSID:     "02:00:00:00:01:00",
+		Frequency: 2412,
+	}
+	if resp.StatusCode != http.StatusOK || res != expect {
+		t.Errorf("got status %d, %+v, expected %+v", resp.StatusCode, res, expect)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	c, ts := newTestServer(t, "")
+
+	resp := do(t, "GET", ts.URL+"/events", "", "")
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("got Content-Type %q", ct)
+	}
+
+	// The response headers are sent after subscribing, so the event
+	// won't be dropped.
+	c.events <- wpasupplicant.WPAEvent{
+		Event:     "DISCONNECTED",
+		Arguments: map[string]string{"reason": "3"},
+		Line:      "CTRL-EVENT-DISCONNECTED bssid=02:00:00:00:01:00 reason=3",
+	}
+
+	r := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 2 {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+
+	expect := []string{
+		"event: DISCONNECTED",
+		`data: {"event":"DISCONNECTED","arguments":{"reason":"3"},"line":"CTRL-EVENT-DISCONNECTED bssid=02:00:00:00:01:00 reason=3"}`,
+	}
+	for i := range expect {
+		if lines[i] != expect[i] {
+			t.Errorf("line %d: got %q, expected %q", i, lines[i], expect[i])
+		}
+	}
+}
+
+func TestEventsRedacted(t *testing.T) {
+	c, ts := newTestServer(t, "")
+
+	resp := do(t, "GET", ts.URL+"/events", "", "")
+	defer resp.Body.Close()
+
+	c.events <- wpasupplicant.WPAEvent{
+		Event:     "P2P-GROUP-STARTED",
+		Arguments: map[string]string{"freq": "2412", "passphrase": `"12345678"`},
+		Line:      `P2P-GROUP-STARTED p2p-wlan0-0 GO ssid="DIRECT-ab" freq=2412 passphrase="12345678" go_dev_addr=02:00:00:00:00:01`,
+	}
+
+	r := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 2 {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+
+	if strings.Contains(lines[1], "12345678") || strings.Contains(lines[1], "passphrase") {
+		t.Errorf("secret streamed to client: %s", lines[1])
+	}
+	if !strings.Contains(lines[1], "go_dev_addr=02:00:00:00:00:01") {
+		t.Errorf("event not streamed: %s", lines[1])
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SignalPollResult is the state of the current link, from SIGNAL_POLL.
+type SignalPollResult interface {
+	// RSSI is the received signal strength, in dBm.
+	RSSI() int
+
+	// LinkSpeed is the transmit rate, in Mbps.
+	LinkSpeed() int
+
+	// Noise is the noise level, in dBm.  Drivers which don't report it
+	// return 9999.
+	Noise() int
+
+	// Frequency is the frequency, in Mhz, of the current channel.
+	Frequency() int
+
+	// Width is the channel width, e.g. "20 MHz" or "80+80 MHz".
+	Width() string
+
+	// CenterFrequency1 and CenterFrequency2 are the center frequencies,
+	// in Mhz, of the channel segments.  CenterFrequency2 is only set for
+	// 80+80 MHz channels.
+	CenterFrequency1() int
+	CenterFrequency2() int
+
+	// AverageRSSI is the average received signal strength, in dBm.
+	AverageRSSI() int
+
+	// AverageBeaconRSSI is the average signal strength of received
+	// beacons, in dBm.
+	AverageBeaconRSSI() int
+}
+
+type signalPollResult struct {
+	rssi              int
+	linkSpeed         int
+	noise             int
+	frequency         int
+	width             string
+	centerFrequency1  int
+	centerFrequency2  int
+	averageRSSI       int
+	averageBeaconRSSI int
+}
+
+func (r *signalPollResult) RSSI() int              { return r.rssi }
+func (r *signalPollResult) LinkSpeed() int         { return r.linkSpeed }
+func (r *signalPollResult) Noise() int             { return r.noise }
+func (r *signalPollResult) Frequency() int         { return r.frequency }
+func (r *signalPollResult) Width() string          { return r.width }
+func (r *signalPollResult) CenterFrequency1() int  { return r.centerFrequency1 }
+func (r *signalPollResult) CenterFrequency2() int  { return r.centerFrequency2 }
+func (r *signalPollResult) AverageRSSI() int       { return r.averageRSSI }
+func (r *signalPollResult) AverageBeaconRSSI() int { return r.averageBeaconRSSI }
+
+// PacketCountResult is the packet counters of the current link, from
+// PKTCNT_POLL.
+type PacketCountResult interface {
+	// TxPackets is the number of packets transmitted successfully.
+	TxPackets() uint64
+
+	// TxFailures is the number of packets which failed to transmit.
+	TxFailures() uint64
+
+	// RxPackets is the number of packets received.
+	RxPackets() uint64
+}
+
+type packetCountResult struct {
+	txPackets  uint64
+	txFailures uint64
+	rxPackets  uint64
+}
+
+func (r *packetCountResult) TxPackets() uint64  { return r.txPackets }
+func (r *packetCountResult) TxFailures() uint64 { return r.txFailures }
+func (r *packetCountResult) RxPackets() uint64  { return r.rxPackets }
+
+// parseKeyValues calls fn for each key=value line in resp, stopping at the
+// first error.
+func parseKeyValues(resp io.Reader, fn func(key, value string) error) error {
+	s := bufio.NewScanner(resp)
+	for s.Scan() {
+		ln := s.Text()
+		fields := strings.SplitN(ln, "=", 2)
+		if len(fields) != 2 {
+			continue
+		}
+
+		if err := fn(fields[0], fields[1]); err != nil {
+			return &ParseError{Line: ln, Err: err}
+		}
+	}
+	return s.Err()
+}
+
+// parseSignalPoll parses the SIGNAL_POLL output from wpa_supplicant.
+func parseSignalPoll(resp io.Reader) (SignalPollResult, error) {
+	res := &signalPollResult{}
+
+	err := parseKeyValues(resp, func(key, value string) (err error) {
+		switch key {
+		case "RSSI":
+			res.rssi, err = strconv.Atoi(value)
+		case "LINKSPEED":
+			res.linkSpeed, err = strconv.Atoi(value)
+		case "NOISE":
+			res.noise, err = strconv.Atoi(value)
+		case "FREQUENCY":
+			res.frequency, err = strconv.Atoi(value)
+		case "WIDTH":
+			res.width = value
+		case "CENTER_FRQ1":
+			res.centerFrequency1, err = strconv.Atoi(value)
+		case "CENTER_FRQ2":
+			res.centerFrequency2, err = strconv.Atoi(value)
+		case "AVG_RSSI":
+			res.averageRSSI, err = strconv.Atoi(value)
+		case "AVG_BEACON_RSSI":
+			res.averageBeaconRSSI, err = strconv.Atoi(value)
+		}
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// parsePacketCountPoll parses the PKTCNT_POLL output from wpa_supplicant.
+func parsePacketCountPoll(resp io.Reader) (PacketCountResult, error) {
+	res := &packetCountResult{}
+
+	err := parseKeyValues(resp, func(key, value string) (err error) {
+		switch key {
+		case "TXGOOD":
+			res.txPackets, err = strconv.ParseUint(value, 10, 64)
+		case "TXBAD":
+			res.txFailures, err = strconv.ParseUint(value, 10, 64)
+		case "RXGOOD":
+			res.rxPackets, err = strconv.ParseUint(value, 10, 64)
+		}
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SignalChange is a CTRL-EVENT-SIGNAL-CHANGE event, sent when the signal
+// strength crosses the threshold configured using Conn.SignalMonitor().
+type SignalChange struct {
+	// Above is true if the signal rose above the threshold, and false
+	// if it fell below it.
+	Above bool
+
+	// RSSI is the received signal strength, in dBm.
+	RSSI int
+
+	// Noise is the noise level, in dBm.
+	Noise int
+
+	// TxRate is the transmit rate, in kbps.
+	TxRate int
+}
+
+// ParseSignalChange returns the signal change contained in ev.  The second
+// return value is false if ev is not a signal change event.
+func ParseSignalChange(ev WPAEvent) (*SignalChange, bool) {
+	if ev.Event != "SIGNAL-CHANGE" {
+		return nil, false
+	}
+
+	c := &SignalChange{Above: ev.Arguments["above"] == "1"}
+	for key, v := range map[string]*int{"signal": &c.RSSI, "noise": &c.Noise, "txrate": &c.TxRate} {
+		if s, ok := ev.Arguments[key]; ok {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, false
+			}
+			*v = n
+		}
+	}
+
+	return c, true
+}
+
+// SignalChanges returns a channel of signal change events, read from the
+// Conn's EventQueue until the context is done.  Other events are discarded,
+// so callers should not be reading events from the EventQueue at the same
+// time.
+func SignalChanges(ctx context.Context, c Conn) <-chan SignalChange {
+	changes := make(chan SignalChange)
+
+	go func() {
+		defer close(changes)
+		for {
+			select {
+			case ev := <-c.EventQueue():
+				sc, ok := ParseSignalChange(ev)
+				if !ok {
+					continue
+				}
+				select {
+				case changes <- *sc:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes
+}
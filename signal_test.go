@@ -0,0 +1,140 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseSignalPoll(t *testing.T) {
+	input := "RSSI=-60\n" +
+		"LINKSPEED=866\n" +
+		"NOISE=9999\n" +
+		"FREQUENCY=5180\n" +
+		"WIDTH=80 MHz\n" +
+		"CENTER_FRQ1=5210\n" +
+		"AVG_RSSI=-61\n" +
+		"AVG_BEACON_RSSI=-58\n"
+
+	res, err := parseSignalPoll(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := &signalPollResult{
+		rssi:              -60,
+		linkSpeed:         866,
+		noise:             9999,
+		frequency:         5180,
+		width:             "80 MHz",
+		centerFrequency1:  5210,
+		averageRSSI:       -61,
+		averageBeaconRSSI: -58,
+	}
+	if *res.(*signalPollResult) != *expect {
+		t.Errorf("got %+v, expected %+v", res, expect)
+	}
+
+	if _, err := parseSignalPoll(bytes.NewBufferString("RSSI=loud\n")); err == nil {
+		t.Error("expected error parsing bad RSSI")
+	}
+}
+
+func TestPacketCountPoll(t *testing.T) {
+	_, conn := newFakeServer(t, func(cmd string) string {
+		if cmd == "PKTCNT_POLL" {
+			return "TXGOOD=1234\nTXBAD=5\nRXGOOD=98765\n"
+		}
+		return ""
+	})
+
+	res, err := conn.PacketCountPoll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TxPackets() != 1234 || res.TxFailures() != 5 || res.RxPackets() != 98765 {
+		t.Errorf("wrong counters (got %+v)", res)
+	}
+}
+
+func TestSignalPollNotConnected(t *testing.T) {
+	_, conn := newFakeServer(t, func(cmd string) string {
+		if cmd == "SIGNAL_POLL" {
+			return "FAIL\n"
+		}
+		return ""
+	})
+
+	if _, err := conn.SignalPoll(); err == nil {
+		t.Error("expected error from SIGNAL_POLL")
+	}
+}
+
+func TestSignalMonitor(t *testing.T) {
+	fs, conn := newFakeServer(t, nil)
+
+	if err := conn.SignalMonitor(-70, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	changes := SignalChanges(ctx, conn)
+
+	fs.event("CTRL-EVENT-CONNECTED - Connection to 02:00:00:00:01:00 completed [id=0 id_str=]")
+	fs.event("CTRL-EVENT-SIGNAL-CHANGE above=0 signal=-76 noise=-95 txrate=6500")
+	fs.event("CTRL-EVENT-SIGNAL-CHANGE above=1 signal=-62 noise=-95 txrate=72200")
+
+	expect := []SignalChange{
+		{Above: false, RSSI: -76, Noise: -95, TxRate: 6500},
+		{Above: true, RSSI: -62, Noise: -95, TxRate: 72200},
+	}
+	for _, e := range expect {
+		select {
+		case c := <-changes:
+			if c != e {
+				t.Errorf("got %+v, expected %+v", c, e)
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for signal change")
+		}
+	}
+
+	if err := conn.SignalMonitor(0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	fs.expectCommands(
+		"SIGNAL_MONITOR THRESHOLD=-70 HYSTERESIS=5",
+		"SIGNAL_MONITOR",
+	)
+}
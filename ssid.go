@@ -0,0 +1,187 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// errBadEscape is returned when a string contains an invalid escape
+// sequence.
+var errBadEscape = errors.New("invalid escape sequence")
+
+// printfDecode decodes a string escaped by wpa_supplicant's printf_encode(),
+// which is how SSIDs and other binary strings are printed in command
+// output.
+func printfDecode(s string) (string, error) {
+	if strings.IndexByte(s, '\\') == -1 {
+		return s, nil
+	}
+
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b = append(b, s[i])
+			continue
+		}
+
+		i++
+		if i == len(s) {
+			return "", errBadEscape
+		}
+		switch c := s[i]; c {
+		case '\\', '"':
+			b = append(b, c)
+		case 'n':
+			b = append(b, '\n')
+		case 'r':
+			b = append(b, '\r')
+		case 't':
+			b = append(b, '\t')
+		case 'e':
+			b = append(b, '\033')
+		case 'x':
+			if i+2 >= len(s) {
+				return "", errBadEscape
+			}
+			n, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", errBadEscape
+			}
+			b = append(b, byte(n))
+			i += 2
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			// Up to three octal digits.
+			j := i + 1
+			for j < len(s) && j < i+3 && s[j] >= '0' && s[j] <= '7' {
+				j++
+			}
+			n, err := strconv.ParseUint(s[i:j], 8, 8)
+			if err != nil {
+				return "", errBadEscape
+			}
+			b = append(b, byte(n))
+			i = j - 1
+		default:
+			return "", errBadEscape
+		}
+	}
+
+	return string(b), nil
+}
+
+// SSID is a raw SSID.  SSIDs are up to 32 arbitrary bytes, which are often,
+// but not always, UTF-8.
+type SSID []byte
+
+// ParseSSID decodes an SSID in the escaped form printed by wpa_supplicant,
+// e.g. in SCAN_RESULTS output.
+func ParseSSID(escaped string) (SSID, error) {
+	s, err := printfDecode(escaped)
+	if err != nil {
+		return nil, err
+	}
+	return SSID(s), nil
+}
+
+// Escaped returns the SSID in the escaped form printed by wpa_supplicant.
+// Quotes, backslashes and anything other than printable ASCII are escaped.
+func (s SSID) Escaped() string {
+	return printfEncode(string(s))
+}
+
+// Hex returns the SSID as hex digits, as accepted by SET_NETWORK and
+// wpa_supplicant.conf.
+func (s SSID) Hex() string {
+	return hex.EncodeToString(s)
+}
+
+// Value returns the SSID encoded for SET_NETWORK and wpa_supplicant.conf:
+// quoted if it is printable ASCII, and hex otherwise.
+func (s SSID) Value() string {
+	return encodeValue("ssid", string(s))
+}
+
+// IsPrintable returns true if the SSID is valid UTF-8 without control
+// characters.
+func (s SSID) IsPrintable() bool {
+	if !utf8.Valid(s) {
+		return false
+	}
+	for _, r := range string(s) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the SSID for display: as-is if it is printable UTF-8,
+// and escaped otherwise.
+func (s SSID) String() string {
+	if s.IsPrintable() {
+		return string(s)
+	}
+	return s.Escaped()
+}
+
+// printfEncode escapes a string the same way as wpa_supplicant's
+// printf_encode().
+func printfEncode(s string) string {
+	b := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\033':
+			b.WriteString(`\e`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if c >= 32 && c <= 126 {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(b, `\x%02x`, c)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bytes"
+	"testing"
+)
+
+var ssidTests = []struct {
+	escaped string
+	raw     SSID
+	display string
+	value   string
+}{
+	{`home`, SSID("home"), "home", `"home"`},
+	{`say \"hi\"`, SSID(`say "hi"`), `say "hi"`, `"say "hi""`},
+	{`back\\slash`, SSID(`back\slash`), `back\slash`, `"back\slash"`},
+	{`caf\xc3\xa9`, SSID("caf\xc3\xa9"), "caf\xc3\xa9", "636166c3a9"},
+	{`\x00\x00`, SSID{0, 0}, `\x00\x00`, "0000"},
+	{`\e[1mbold`, SSID("\033[1mbold"), `\e[1mbold`, "1b5b316d626f6c64"},
+	{`bad\xffutf8`, SSID("bad\xffutf8"), `bad\xffutf8`, "626164ff75746638"},
+}
+
+func TestSSID(t *testing.T) {
+	for _, test := range ssidTests {
+		raw, err := ParseSSID(test.escaped)
+		if err != nil {
+			t.Errorf("failed to parse %q: %v", test.escaped, err)
+			continue
+		}
+		if !bytes.Equal(raw, test.raw) {
+			t.Errorf("wrong decoding of %q (got %q, expect %q)", test.escaped, raw, test.raw)
+		}
+		if got := raw.Escaped(); got != test.escaped {
+			t.Errorf("wrong encoding of %q (got %q, expect %q)", raw, got, test.escaped)
+		}
+		if got := raw.String(); got != test.display {
+			t.Errorf("wrong display of %q (got %q, expect %q)", raw, got, test.display)
+		}
+		if got := raw.Value(); got != test.value {
+			t.Errorf("wrong value of %q (got %s, expect %s)", raw, got, test.value)
+		}
+	}
+}
+
+func TestParseSSIDErrors(t *testing.T) {
+	for _, escaped := range []string{`trailing\`, `\x4`, `\xzz`, `\q`} {
+		if _, err := ParseSSID(escaped); err == nil {
+			t.Errorf("expected error parsing %q", escaped)
+		}
+	}
+}
+
+func TestParseEscapedScanResults(t *testing.T) {
+	input := "bssid / frequency / signal level / flags / ssid\n" +
+		"8a:15:14:8a:46:51\t2412\t-58\t[ESS]\t\\x00\\x00\\x00\n" +
+		"8a:15:14:8a:46:52\t2412\t-58\t[ESS]\tcaf\\xc3\\xa9 \\\"wifi\\\"\n"
+
+	res, errs := parseScanResults(bytes.NewBufferString(input))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	if res[0].SSID() != "\x00\x00\x00" {
+		t.Errorf("wrong ssid (got %q)", res[0].SSID())
+	}
+	if res[1].SSID() != "caf\xc3\xa9 \"wifi\"" {
+		t.Errorf("wrong ssid (got %q)", res[1].SSID())
+	}
+}
+
+func TestDecodePrintfValue(t *testing.T) {
+	var cfg NetworkConfig
+	if err := cfg.Set("ssid", `P"caf\xc3\xa9\n"`); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SSID != "caf\xc3\xa9\n" {
+		t.Errorf("wrong ssid (got %q)", cfg.SSID)
+	}
+}
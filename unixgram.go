@@ -39,6 +39,7 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 )
 
@@ -60,6 +61,10 @@ type unixgramConn struct {
 	file                   *os.File
 	solicited, unsolicited chan message
 	wpaEvents              chan WPAEvent
+
+	// cmdMu serializes commands, so each caller receives the response
+	// to its own command.
+	cmdMu sync.Mutex
 }
 
 // socketPath is where to find the the AF_UNIX sockets for each interface.  It
@@ -69,6 +74,13 @@ var socketPath = "/run/wpa_supplicant"
 // Unixgram returns a connection to wpa_supplicant for the specified
 // interface, using the socket-based control interface.
 func Unixgram(ifName string) (Conn, error) {
+	return UnixgramDir(socketPath, ifName)
+}
+
+// UnixgramDir is like Unixgram, but looks for the control interface socket
+// in the specified directory (the ctrl_interface setting in
+// wpa_supplicant.conf), rather than the default of /run/wpa_supplicant.
+func UnixgramDir(dir, ifName string) (Conn, error) {
 	var err error
 	uc := &unixgramConn{}
 
@@ -80,7 +92,7 @@ func Unixgram(ifName string) (Conn, error) {
 
 	uc.c, err = net.DialUnix("unixgram",
 		&net.UnixAddr{Name: local.Name(), Net: "unixgram"},
-		&net.UnixAddr{Name: path.Join(socketPath, ifName), Net: "unixgram"})
+		&net.UnixAddr{Name: path.Join(dir, ifName), Net: "unixgram"})
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +192,7 @@ func (uc *unixgramConn) readUnsolicited() {
 			continue
 		}
 
-		if strings.Index(parts[0], "CTRL-") != 0 {
+		if strings.Index(parts[0], "CTRL-") != 0 && !hasEventPrefix(parts[0]) {
 			uc.wpaEvents <- WPAEvent{
 				Event: "MESSAGE",
 				Line:  data,
@@ -194,6 +206,13 @@ func (uc *unixgramConn) readUnsolicited() {
 			Line:      data,
 		}
 
+		// Credential requests encode the field and network ID in the
+		// event name, e.g. "CTRL-REQ-PASSWORD-1:Password needed".  See
+		// ParseCredentialRequest().
+		if strings.HasPrefix(parts[0], "CTRL-REQ-") {
+			event.Event = "CTRL-REQ"
+		}
+
 		for _, args := range parts[1:] {
 			if strings.Contains(args, "=") {
 				keyval := strings.Split(args, "=")
@@ -208,9 +227,31 @@ func (uc *unixgramConn) readUnsolicited() {
 	}
 }
 
+// eventPrefixes are the prefixes of unsolicited messages which, like
+// CTRL-EVENT-*, are events with key=value arguments.  Unlike CTRL-EVENT-,
+// the prefix is kept as part of the event name.
+var eventPrefixes = []string{
+	"ANQP-",
+	"DPP-",
+	"INTERWORKING-",
+	"MESH-",
+	"P2P-",
+	"WPS-",
+}
+
+func hasEventPrefix(s string) bool {
+	for _, prefix := range eventPrefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // cmd executes a command and waits for a reply.
 func (uc *unixgramConn) cmd(cmd string) ([]byte, error) {
-	// TODO: block if any other commands are running
+	uc.cmdMu.Lock()
+	defer uc.cmdMu.Unlock()
 
 	_, err := uc.c.Write([]byte(cmd))
 	if err != nil {
@@ -267,6 +308,11 @@ func (uc *unixgramConn) Close() error {
 	return nil
 }
 
+func (uc *unixgramConn) Command(cmd string) (string, error) {
+	resp, err := uc.cmd(cmd)
+	return string(resp), err
+}
+
 func (uc *unixgramConn) Ping() error {
 	resp, err := uc.cmd("PING")
 	if err != nil {
@@ -314,16 +360,7 @@ func (uc *unixgramConn) RemoveAllNetworks() error {
 }
 
 func (uc *unixgramConn) SetNetwork(networkID int, variable string, value string) error {
-	var cmd string
-
-	// Since key_mgmt and priority expects the value to not be wrapped in "" we do a little check here.
-	if variable == "key_mgmt" || variable == "priority" {
-		cmd = fmt.Sprintf("SET_NETWORK %d %s %s", networkID, variable, value)
-	} else {
-		cmd = fmt.Sprintf("SET_NETWORK %d %s \"%s\"", networkID, variable, value)
-	}
-
-	return uc.runCommand(cmd)
+	return uc.runCommand(fmt.Sprintf("SET_NETWORK %d %s %s", networkID, variable, encodeValue(variable, value)))
 }
 
 func (uc *unixgramConn) GetNetwork(networkID int, variable string) (string, error) {
@@ -336,6 +373,26 @@ func (uc *unixgramConn) GetNetwork(networkID int, variable string) (string, erro
 	return s, nil
 }
 
+func (uc *unixgramConn) ApplyNetwork(networkID int, cfg NetworkConfig) error {
+	return applyNetwork(uc, networkID, cfg)
+}
+
+func (uc *unixgramConn) ReadNetwork(networkID int) (NetworkConfig, error) {
+	return readNetwork(uc, networkID)
+}
+
+func (uc *unixgramConn) Respond(req *CredentialRequest, value string) error {
+	return uc.runCommand(req.response(value))
+}
+
+func (uc *unixgramConn) SetBlob(name string, data []byte) error {
+	return uc.runCommand(blobCommand(name, data))
+}
+
+func (uc *unixgramConn) SetSAEPWE(pwe SAEPWE) error {
+	return uc.runCommand(fmt.Sprintf("SET sae_pwe %d", pwe))
+}
+
 func (uc *unixgramConn) SaveConfig() error {
 	return uc.runCommand("SAVE_CONFIG")
 }
@@ -365,6 +422,31 @@ func (uc *unixgramConn) ScanResults() ([]ScanResult, []error) {
 	return parseScanResults(bytes.NewBuffer(resp))
 }
 
+func (uc *unixgramConn) AbortScan() error {
+	return uc.runCommand("ABORT_SCAN")
+}
+
+func (uc *unixgramConn) SetScanInterval(seconds int) error {
+	return uc.runCommand(fmt.Sprintf("SCAN_INTERVAL %d", seconds))
+}
+
+func (uc *unixgramConn) Autoscan(spec string) error {
+	// An empty parameter (but not a missing one) disables autoscan.
+	return uc.runCommand("AUTOSCAN " + spec)
+}
+
+func (uc *unixgramConn) FlushBSS(age int) error {
+	return uc.runCommand(fmt.Sprintf("BSS_FLUSH %d", age))
+}
+
+func (uc *unixgramConn) SetBSSExpireAge(seconds int) error {
+	return uc.runCommand(fmt.Sprintf("BSS_EXPIRE_AGE %d", seconds))
+}
+
+func (uc *unixgramConn) SetBSSExpireCount(scans int) error {
+	return uc.runCommand(fmt.Sprintf("BSS_EXPIRE_COUNT %d", scans))
+}
+
 func (uc *unixgramConn) Status() (StatusResult, error) {
 	resp, err := uc.cmd("STATUS")
 	if err != nil {
@@ -374,6 +456,37 @@ func (uc *unixgramConn) Status() (StatusResult, error) {
 	return parseStatusResults(bytes.NewBuffer(resp))
 }
 
+func (uc *unixgramConn) SignalPoll() (SignalPollResult, error) {
+	resp, err := uc.cmd("SIGNAL_POLL")
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Compare(resp, []byte("FAIL\n")) == 0 {
+		return nil, &ParseError{Line: string(resp)}
+	}
+	return parseSignalPoll(bytes.NewBuffer(resp))
+}
+
+func (uc *unixgramConn) PacketCountPoll() (PacketCountResult, error) {
+	resp, err := uc.cmd("PKTCNT_POLL")
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Compare(resp, []byte("FAIL\n")) == 0 {
+		return nil, &ParseError{Line: string(resp)}
+	}
+	return parsePacketCountPoll(bytes.NewBuffer(resp))
+}
+
+func (uc *unixgramConn) SignalMonitor(threshold, hysteresis int) error {
+	if threshold == 0 {
+		return uc.runCommand("SIGNAL_MONITOR")
+	}
+	return uc.runCommand(fmt.Sprintf("SIGNAL_MONITOR THRESHOLD=%d HYSTERESIS=%d", threshold, hysteresis))
+}
+
 func (uc *unixgramConn) ListNetworks() ([]ConfiguredNetwork, error) {
 	resp, err := uc.cmd("LIST_NETWORKS")
 	if err != nil {
@@ -398,6 +511,21 @@ func (uc *unixgramConn) runCommand(cmd string) error {
 	return &ParseError{Line: string(resp)}
 }
 
+// runOK sends a command using c.Command(), making sure it returned a
+// successful (OK) response.  It is used by the types which wrap a Conn,
+// such as P2P.
+func runOK(c Conn, cmd string) error {
+	resp, err := c.Command(cmd)
+	if err != nil {
+		return err
+	}
+
+	if resp != "OK\n" {
+		return &ParseError{Line: resp}
+	}
+	return nil
+}
+
 func parseListNetworksResult(resp io.Reader) (res []ConfiguredNetwork, err error) {
 	s := bufio.NewScanner(resp)
 	if !s.Scan() {
@@ -427,19 +555,25 @@ func parseListNetworksResult(resp io.Reader) (res []ConfiguredNetwork, err error
 			return nil, &ParseError{Line: ln}
 		}
 
-		var networkID string
+		networkID := -1
 		if networkIDCol != -1 {
-			networkID = fields[networkIDCol]
+			if networkID, err = strconv.Atoi(fields[networkIDCol]); err != nil {
+				return nil, &ParseError{Line: ln, Err: err}
+			}
 		}
 
 		var ssid string
 		if ssidCol != -1 {
-			ssid = fields[ssidCol]
+			if ssid, err = printfDecode(fields[ssidCol]); err != nil {
+				return nil, &ParseError{Line: ln, Err: err}
+			}
 		}
 
-		var bssid string
-		if bssidCol != -1 {
-			bssid = fields[bssidCol]
+		var bssid net.HardwareAddr
+		if bssidCol != -1 && fields[bssidCol] != "any" {
+			if bssid, err = net.ParseMAC(fields[bssidCol]); err != nil {
+				return nil, &ParseError{Line: ln, Err: err}
+			}
 		}
 
 		var flags []string
@@ -465,9 +599,10 @@ func parseStatusResults(resp io.Reader) (StatusResult, error) {
 
 	res := &statusResult{}
 
+	var err error
 	for s.Scan() {
 		ln := s.Text()
-		fields := strings.Split(ln, "=")
+		fields := strings.SplitN(ln, "=", 2)
 		if len(fields) != 2 {
 			continue
 		}
@@ -480,9 +615,19 @@ func parseStatusResults(resp io.Reader) (StatusResult, error) {
 		case "ip_address":
 			res.ipAddr = fields[1]
 		case "ssid":
-			res.ssid = fields[1]
+			if res.ssid, err = printfDecode(fields[1]); err != nil {
+				return nil, &ParseError{Line: ln, Err: err}
+			}
 		case "address":
 			res.address = fields[1]
+		case "bssid":
+			if res.bssid, err = net.ParseMAC(fields[1]); err != nil {
+				return nil, &ParseError{Line: ln, Err: err}
+			}
+		case "freq":
+			if res.frequency, err = strconv.Atoi(fields[1]); err != nil {
+				return nil, &ParseError{Line: ln, Err: err}
+			}
 		}
 	}
 
@@ -559,7 +704,10 @@ func parseScanResults(resp io.Reader) (res []ScanResult, errs []error) {
 
 		var ssid string
 		if ssidCol != -1 {
-			ssid = fields[ssidCol]
+			if ssid, err = printfDecode(fields[ssidCol]); err != nil {
+				errs = append(errs, &ParseError{Line: ln, Err: err})
+				continue
+			}
 		}
 
 		res = append(res, &scanResult{
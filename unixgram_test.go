@@ -30,10 +30,131 @@ package wpasupplicant
 
 import (
 	"bytes"
+	"io/ioutil"
 	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
+// fakeServer is a stand-in for the wpa_supplicant control socket.  It
+// records the commands it receives and answers them using a handler
+// function.
+type fakeServer struct {
+	t       *testing.T
+	c       *net.UnixConn
+	handler func(cmd string) string
+
+	mu   sync.Mutex
+	cmds []string
+	peer *net.UnixAddr
+}
+
+// newFakeServer starts a fakeServer and returns a Conn connected to it.
+// Commands for which handler returns an empty string are answered with
+// "OK\n".
+func newFakeServer(t *testing.T, handler func(cmd string) string) (*fakeServer, Conn) {
+	dir, err := ioutil.TempDir("", "wpasupplicant_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldSocketPath := socketPath
+	socketPath = dir
+
+	c, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path.Join(dir, "wlan0"), Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := &fakeServer{t: t, c: c, handler: handler}
+	go fs.serve()
+
+	conn, err := Unixgram("wlan0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		socketPath = oldSocketPath
+		c.Close()
+		os.RemoveAll(dir)
+	})
+
+	return fs, conn
+}
+
+func (fs *fakeServer) serve() {
+	buf := make([]byte, 4096)
+	for {
+		n, addr, err := fs.c.ReadFromUnix(buf)
+		if err != nil {
+			return
+		}
+
+		cmd := string(buf[:n])
+		fs.mu.Lock()
+		fs.cmds = append(fs.cmds, cmd)
+		fs.peer = addr
+		fs.mu.Unlock()
+
+		var resp string
+		if fs.handler != nil {
+			resp = fs.handler(cmd)
+		}
+		if resp == "" {
+			resp = "OK\n"
+		}
+		fs.c.WriteToUnix([]byte(resp), addr)
+	}
+}
+
+// event sends an unsolicited message to the connected client.
+func (fs *fakeServer) event(line string) {
+	fs.mu.Lock()
+	peer := fs.peer
+	fs.mu.Unlock()
+
+	if _, err := fs.c.WriteToUnix([]byte("<2>"+line), peer); err != nil {
+		fs.t.Error(err)
+	}
+}
+
+// commands returns the commands received so far, excluding ATTACH.
+func (fs *fakeServer) commands() []string {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	var cmds []string
+	for _, cmd := range fs.cmds {
+		if cmd != "ATTACH" {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds
+}
+
+// expectCommands checks that the fakeServer received exactly the expected
+// commands, in order.
+func (fs *fakeServer) expectCommands(expect ...string) {
+	fs.t.Helper()
+
+	cmds := fs.commands()
+	if len(cmds) != len(expect) {
+		fs.t.Errorf("got commands %q, expected %q", cmds, expect)
+		return
+	}
+	for i := range cmds {
+		if cmds[i] != expect[i] {
+			fs.t.Errorf("got commands %q, expected %q", cmds, expect)
+			return
+		}
+	}
+}
+
 var parseScanResultTests = []struct {
 	input  string
 	expect []*scanResult
@@ -164,4 +285,143 @@ func TestParseStatusResults(t *testing.T) {
 	if res.Address() != "" {
 		t.Errorf("Address should be empty. Was %s", res.Address())
 	}
+
+	if res.BSSID().String() != "02:00:01:02:03:04" {
+		t.Errorf("BSSID was not 02:00:01:02:03:04. Was %s", res.BSSID())
+	}
+
+	// Printf escaping doesn't escape "=".
+	res, err = parseStatusResults(bytes.NewBufferString("ssid=a=b\\x00\n"))
+	if err != nil {
+		t.Errorf("Error parsing status result %s", err)
+	}
+	if res.SSID() != "a=b\x00" {
+		t.Errorf("SSID was not a=b\\x00. Was %q", res.SSID())
+	}
+}
+
+func TestScanControl(t *testing.T) {
+	fs, conn := newFakeServer(t, nil)
+
+	if err := conn.AbortScan(); err != nil {
+		t.Error(err)
+	}
+	if err := conn.SetScanInterval(30); err != nil {
+		t.Error(err)
+	}
+	if err := conn.Autoscan(AutoscanExponential(3, 300)); err != nil {
+		t.Error(err)
+	}
+	if err := conn.Autoscan(AutoscanPeriodic(60)); err != nil {
+		t.Error(err)
+	}
+	if err := conn.Autoscan(""); err != nil {
+		t.Error(err)
+	}
+	if err := conn.FlushBSS(0); err != nil {
+		t.Error(err)
+	}
+	if err := conn.SetBSSExpireAge(180); err != nil {
+		t.Error(err)
+	}
+	if err := conn.SetBSSExpireCount(2); err != nil {
+		t.Error(err)
+	}
+
+	fs.expectCommands(
+		"ABORT_SCAN",
+		"SCAN_INTERVAL 30",
+		"AUTOSCAN exponential:3:300",
+		"AUTOSCAN periodic:60",
+		"AUTOSCAN ",
+		"BSS_FLUSH 0",
+		"BSS_EXPIRE_AGE 180",
+		"BSS_EXPIRE_COUNT 2",
+	)
+}
+
+func TestScanControlFailure(t *testing.T) {
+	_, conn := newFakeServer(t, func(cmd string) string {
+		if cmd == "ABORT_SCAN" {
+			return "FAIL\n"
+		}
+		return ""
+	})
+
+	if err := conn.AbortScan(); err == nil {
+		t.Error("expected error from ABORT_SCAN")
+	}
+}
+
+func TestConcurrentCommands(t *testing.T) {
+	_, conn := newFakeServer(t, func(cmd string) string {
+		if strings.HasPrefix(cmd, "GET_NETWORK ") {
+			return strings.Fields(cmd)[1]
+		}
+		return ""
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := conn.GetNetwork(i, "ssid")
+			if err != nil {
+				t.Error(err)
+			} else if resp != strconv.Itoa(i) {
+				t.Errorf("network %d: got response %q", i, resp)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestParseListNetworksResult(t *testing.T) {
+	input := "network id / ssid / bssid / flags\n" +
+		"0\thome\tany\t[CURRENT]\n" +
+		"1\tcaf\\xc3\\xa9 \\\"bar\\\"\t02:00:00:00:01:00\t[DISABLED]\n" +
+		"2\toffice\tany\t[TEMP-DISABLED]\n" +
+		"3\tDIRECT-ab\tany\t[DISABLED][P2P-PERSISTENT]\n" +
+		"4\tguest\tany\t\n"
+
+	res, err := parseListNetworksResult(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 5 {
+		t.Fatalf("wrong number of networks (got %d, expect 5)", len(res))
+	}
+
+	for i, n := range res {
+		if n.NetworkID() != i {
+			t.Errorf("wrong network id (got %d, expect %d)", n.NetworkID(), i)
+		}
+	}
+
+	if res[1].SSID() != "caf\xc3\xa9 \"bar\"" {
+		t.Errorf("wrong ssid (got %q)", res[1].SSID())
+	}
+	if res[0].BSSID() != nil {
+		t.Errorf("expected any bssid, got %s", res[0].BSSID())
+	}
+	if res[1].BSSID().String() != "02:00:00:00:01:00" {
+		t.Errorf("wrong bssid (got %s)", res[1].BSSID())
+	}
+
+	flags := []struct {
+		current, disabled, tempDisabled, p2pPersistent bool
+	}{
+		{true, false, false, false},
+		{false, true, false, false},
+		{false, false, true, false},
+		{false, true, false, true},
+		{false, false, false, false},
+	}
+	for i, expect := range flags {
+		n := res[i]
+		if n.Current() != expect.current || n.Disabled() != expect.disabled || n.TempDisabled() != expect.tempDisabled || n.P2PPersistent() != expect.p2pPersistent {
+			t.Errorf("wrong flags for network %d (got %q)", i, n.Flags())
+		}
+	}
 }
@@ -0,0 +1,503 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package wpaconf reads and writes wpa_supplicant.conf files, so they can be
+// inspected or edited without a running wpa_supplicant.
+//
+// Network blocks are parsed into the same wpasupplicant.NetworkConfig used
+// with a live connection.  When writing a file back out, comments, ordering
+// and the formatting of unchanged lines are preserved.
+package wpaconf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"pifke.org/wpasupplicant"
+)
+
+// File is a parsed wpa_supplicant.conf file.
+type File struct {
+	// Items are the contents of the file, in order.
+	Items []Item
+}
+
+// Item is a top-level element of a wpa_supplicant.conf file: a *Line, a
+// *Setting, a *Network, a *Cred or a *Blob.
+type Item interface {
+	write(w *bufio.Writer)
+}
+
+// Line is a comment or blank line.
+type Line struct {
+	Text string
+}
+
+func (l *Line) write(w *bufio.Writer) {
+	w.WriteString(l.Text)
+	w.WriteByte('\n')
+}
+
+// Setting is a name=value pair, either a global setting or a variable
+// inside a block.  Value is as written in the file, including any quotes.
+type Setting struct {
+	Name  string
+	Value string
+
+	// raw is the original line, which is written back if Name and Value
+	// haven't changed.
+	raw, origName, origValue string
+}
+
+func (s *Setting) write(w *bufio.Writer) {
+	if s.raw != "" && s.Name == s.origName && s.Value == s.origValue {
+		w.WriteString(s.raw)
+	} else {
+		fmt.Fprintf(w, "%s=%s", s.Name, s.Value)
+	}
+	w.WriteByte('\n')
+}
+
+// Network is a network={...} block.
+type Network struct {
+	// Config is the network's configuration.
+	Config wpasupplicant.NetworkConfig
+
+	// Other holds the variables which Config doesn't support.
+	Other []*Setting
+
+	// lines are the original contents of the block, used to preserve
+	// comments and ordering.
+	lines []blockLine
+}
+
+// blockLine is a line inside a network block.
+type blockLine struct {
+	raw   string
+	name  string // empty for comments and blank lines
+	value string
+	other *Setting
+}
+
+func (n *Network) write(w *bufio.Writer) {
+	w.WriteString("network={\n")
+
+	written := make(map[string]bool)
+	for _, l := range n.lines {
+		switch {
+		case l.name == "":
+			w.WriteString(l.raw)
+			w.WriteByte('\n')
+
+		case l.other != nil:
+			if n.hasOther(l.other) {
+				l.other.write(w)
+				written[l.other.Name] = true
+			}
+
+		case !written[l.name]:
+			// Variables with their zero value are omitted, unless
+			// they were explicitly set to it in the file.
+			value, _ := n.Config.Get(l.name)
+			switch {
+			case value == l.value:
+				w.WriteString(l.raw)
+				w.WriteByte('\n')
+			case value != "":
+				fmt.Fprintf(w, "\t%s=%s\n", l.name, value)
+			}
+			written[l.name] = true
+		}
+	}
+
+	// Anything added since the block was parsed goes at the end.
+	for _, name := range wpasupplicant.NetworkVariables() {
+		if value, ok := n.Config.Get(name); ok && !written[name] {
+			fmt.Fprintf(w, "\t%s=%s\n", name, value)
+		}
+	}
+	for _, s := range n.Other {
+		if s.raw == "" {
+			fmt.Fprintf(w, "\t%s=%s\n", s.Name, s.Value)
+		}
+	}
+
+	w.WriteString("}\n")
+}
+
+// hasOther returns true if s is still in n.Other.
+func (n *Network) hasOther(s *Setting) bool {
+	for _, o := range n.Other {
+		if o == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Cred is a cred={...} block, used for Interworking/Hotspot 2.0
+// credentials.
+type Cred struct {
+	// Items are the contents of the block: *Settings and *Lines.
+	Items []Item
+}
+
+func (c *Cred) write(w *bufio.Writer) {
+	w.WriteString("cred={\n")
+	for _, item := range c.Items {
+		item.write(w)
+	}
+	w.WriteString("}\n")
+}
+
+// Get returns the value of a variable in the block, as written in the file.
+func (c *Cred) Get(name string) (string, bool) {
+	for _, item := range c.Items {
+		if s, ok := item.(*Setting); ok && s.Name == name {
+			return s.Value, true
+		}
+	}
+	return "", false
+}
+
+// Blob is a named binary blob, such as a certificate, which network blocks
+// can refer to as "blob://<name>".
+type Blob struct {
+	Name string
+	Data []byte
+
+	// raw is the original block, which is written back if Data hasn't
+	// changed.
+	raw      []string
+	origData []byte
+}
+
+func (b *Blob) write(w *bufio.Writer) {
+	if b.raw != nil && bytes.Equal(b.Data, b.origData) {
+		for _, ln := range b.raw {
+			w.WriteString(ln)
+			w.WriteByte('\n')
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "blob-base64-%s={\n", b.Name)
+	enc := base64.StdEncoding.EncodeToString(b.Data)
+	for len(enc) > 64 {
+		w.WriteString(enc[:64])
+		w.WriteByte('\n')
+		enc = enc[64:]
+	}
+	if enc != "" {
+		w.WriteString(enc)
+		w.WriteByte('\n')
+	}
+	w.WriteString("}\n")
+}
+
+// SyntaxError is returned when a wpa_supplicant.conf file can't be parsed.
+type SyntaxError struct {
+	// Line is the line number, starting at 1.
+	Line int
+
+	// Msg describes the problem.
+	Msg string
+}
+
+func (err *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", err.Line, err.Msg)
+}
+
+// ParseFile reads and parses a wpa_supplicant.conf file.
+func ParseFile(name string) (*File, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse parses the contents of a wpa_supplicant.conf file.
+func Parse(r io.Reader) (*File, error) {
+	supported := make(map[string]bool)
+	for _, name := range wpasupplicant.NetworkVariables() {
+		supported[name] = true
+	}
+
+	s := bufio.NewScanner(r)
+	f := &File{}
+	lineNum := 0
+
+	next := func() (string, bool) {
+		if !s.Scan() {
+			return "", false
+		}
+		lineNum++
+		return s.Text(), true
+	}
+
+	for {
+		raw, ok := next()
+		if !ok {
+			break
+		}
+
+		name, value, ok := splitLine(raw)
+		switch {
+		case !ok:
+			f.Items = append(f.Items, &Line{Text: raw})
+
+		case name == "network" && value == "{":
+			n := &Network{}
+			for {
+				raw, ok := next()
+				if !ok {
+					return nil, &SyntaxError{lineNum, "unterminated network block"}
+				}
+				if strings.TrimSpace(raw) == "}" {
+					break
+				}
+
+				name, value, ok := splitLine(raw)
+				if !ok {
+					n.lines = append(n.lines, blockLine{raw: raw})
+					continue
+				}
+
+				l := blockLine{raw: raw, name: name}
+				if supported[name] {
+					if err := n.Config.Set(name, value); err != nil {
+						return nil, &SyntaxError{lineNum, err.Error()}
+					}
+					// Normalize, so we can tell if it changes.
+					l.value, _ = n.Config.Get(name)
+				} else {
+					l.other = newSetting(raw, name, value)
+					n.Other = append(n.Other, l.other)
+				}
+				n.lines = append(n.lines, l)
+			}
+			f.Items = append(f.Items, n)
+
+		case name == "cred" && value == "{":
+			c := &Cred{}
+			for {
+				raw, ok := next()
+				if !ok {
+					return nil, &SyntaxError{lineNum, "unterminated cred block"}
+				}
+				if strings.TrimSpace(raw) == "}" {
+					break
+				}
+
+				if name, value, ok := splitLine(raw); ok {
+					c.Items = append(c.Items, newSetting(raw, name, value))
+				} else {
+					c.Items = append(c.Items, &Line{Text: raw})
+				}
+			}
+			f.Items = append(f.Items, c)
+
+		case strings.HasPrefix(name, "blob-base64-") && value == "{":
+			b := &Blob{
+				Name: strings.TrimPrefix(name, "blob-base64-"),
+				raw:  []string{raw},
+			}
+			var enc strings.Builder
+			for {
+				raw, ok := next()
+				if !ok {
+					return nil, &SyntaxError{lineNum, "unterminated blob"}
+				}
+				b.raw = append(b.raw, raw)
+				if strings.TrimSpace(raw) == "}" {
+					break
+				}
+				enc.WriteString(strings.TrimSpace(raw))
+			}
+
+			var err error
+			if b.Data, err = base64.StdEncoding.DecodeString(enc.String()); err != nil {
+				return nil, &SyntaxError{lineNum, fmt.Sprintf("invalid blob %s: %s", b.Name, err)}
+			}
+			b.origData = b.Data
+			f.Items = append(f.Items, b)
+
+		default:
+			f.Items = append(f.Items, newSetting(raw, name, value))
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// newSetting returns a Setting parsed from raw.
+func newSetting(raw, name, value string) *Setting {
+	return &Setting{
+		Name:      name,
+		Value:     value,
+		raw:       raw,
+		origName:  name,
+		origValue: value,
+	}
+}
+
+// splitLine splits a line into a name and value.  The last return value is
+// false for comments and blank lines.  Like wpa_supplicant, comments
+// following a value are ignored, unless they're inside quotes.
+func splitLine(raw string) (name, value string, ok bool) {
+	ln := strings.TrimSpace(raw)
+	if ln == "" || ln[0] == '#' {
+		return "", "", false
+	}
+
+	comment := ln
+	if i := strings.IndexByte(ln, '"'); i != -1 {
+		if j := strings.LastIndexByte(ln, '"'); j > i {
+			comment = ln[j:]
+		}
+	}
+	if i := strings.IndexByte(comment, '#'); i != -1 {
+		ln = strings.TrimSpace(ln[:len(ln)-len(comment)+i])
+	}
+
+	i := strings.IndexByte(ln, '=')
+	if i == -1 {
+		return "", "", false
+	}
+	return ln[:i], ln[i+1:], true
+}
+
+// WriteTo writes the file to w.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	b := &bytes.Buffer{}
+	bw := bufio.NewWriter(b)
+	for _, item := range f.Items {
+		item.write(bw)
+	}
+	bw.Flush()
+
+	return b.WriteTo(w)
+}
+
+// WriteFile writes the file to the named path.
+func (f *File) WriteFile(name string) error {
+	out, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.WriteTo(out); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Global returns the value of a global setting, as written in the file.
+func (f *File) Global(name string) (string, bool) {
+	for _, item := range f.Items {
+		if s, ok := item.(*Setting); ok && s.Name == name {
+			return s.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetGlobal changes the value of a global setting, adding it after the
+// last global setting if it doesn't exist.
+func (f *File) SetGlobal(name, value string) {
+	for _, item := range f.Items {
+		if s, ok := item.(*Setting); ok && s.Name == name {
+			s.Value = value
+			return
+		}
+	}
+
+	i := 0
+globals:
+	for j, item := range f.Items {
+		switch item.(type) {
+		case *Setting:
+			i = j + 1
+		case *Line:
+		default:
+			break globals
+		}
+	}
+
+	f.Items = append(f.Items[:i], append([]Item{&Setting{Name: name, Value: value}}, f.Items[i:]...)...)
+}
+
+// Networks returns the network blocks, in order.
+func (f *File) Networks() []*Network {
+	var networks []*Network
+	for _, item := range f.Items {
+		if n, ok := item.(*Network); ok {
+			networks = append(networks, n)
+		}
+	}
+	return networks
+}
+
+// AddNetwork appends a network block.
+func (f *File) AddNetwork(cfg wpasupplicant.NetworkConfig) *Network {
+	n := &Network{Config: cfg}
+	f.Items = append(f.Items, n)
+	return n
+}
+
+// Remove removes an item, such as a network block, from the file.
+func (f *File) Remove(item Item) {
+	for i := range f.Items {
+		if f.Items[i] == item {
+			f.Items = append(f.Items[:i], f.Items[i+1:]...)
+			return
+		}
+	}
+}
+
+// Blob returns the named blob, or nil if it doesn't exist.
+func (f *File) Blob(name string) *Blob {
+	for _, item := range f.Items {
+		if b, ok := item.(*Blob); ok && b.Name == name {
+			return b
+		}
+	}
+	return nil
+}
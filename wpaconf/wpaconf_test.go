@@ -0,0 +1,205 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpaconf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"pifke.org/wpasupplicant"
+)
+
+const testConf = `# Managed by the image builder
+ctrl_interface=DIR=/var/run/wpa_supplicant GROUP=netdev
+update_config=1
+country=US
+
+# Home network
+network={
+	ssid="home # not a comment"
+	psk="correct horse"   # the passphrase
+	key_mgmt=WPA-PSK
+	priority=5
+	disabled=0
+	# keep this one
+	bgscan="simple:30:-45:300"
+	sim_num=1
+}
+
+network={
+    ssid=636166c3a9
+    key_mgmt=NONE
+}
+
+cred={
+	realm="example.com"
+	username="user"
+	eap=TTLS
+}
+
+blob-base64-ca={
+SGVsbG8g
+V29ybGQh
+}
+`
+
+func TestParse(t *testing.T) {
+	f, err := Parse(strings.NewReader(testConf))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := f.Global("country"); v != "US" {
+		t.Errorf("wrong country (got %q)", v)
+	}
+
+	networks := f.Networks()
+	if len(networks) != 2 {
+		t.Fatalf("wrong number of networks (got %d, expect 2)", len(networks))
+	}
+
+	expect := wpasupplicant.NetworkConfig{
+		SSID:     "home # not a comment",
+		PSK:      "correct horse",
+		KeyMgmt:  "WPA-PSK",
+		Priority: 5,
+		Bgscan:   "simple:30:-45:300",
+	}
+	if networks[0].Config != expect {
+		t.Errorf("got %+v, expected %+v", networks[0].Config, expect)
+	}
+	if len(networks[0].Other) != 1 || networks[0].Other[0].Name != "sim_num" {
+		t.Errorf("unsupported variable not preserved (got %+v)", networks[0].Other)
+	}
+
+	if networks[1].Config.SSID != "caf\xc3\xa9" {
+		t.Errorf("wrong hex SSID (got %q)", networks[1].Config.SSID)
+	}
+
+	var cred *Cred
+	for _, item := range f.Items {
+		if c, ok := item.(*Cred); ok {
+			cred = c
+		}
+	}
+	if v, _ := cred.Get("realm"); v != `"example.com"` {
+		t.Errorf("wrong realm (got %q)", v)
+	}
+
+	if b := f.Blob("ca"); b == nil || string(b.Data) != "Hello World!" {
+		t.Errorf("wrong blob (got %+v)", b)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	f, err := Parse(strings.NewReader(testConf))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &bytes.Buffer{}
+	if _, err = f.WriteTo(b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != testConf {
+		t.Errorf("round trip changed file:\n%s", b.String())
+	}
+}
+
+func TestEdit(t *testing.T) {
+	f, err := Parse(strings.NewReader(testConf))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	home := f.Networks()[0]
+	home.Config.Priority = 0
+	home.Config.PSK = strings.Repeat("ab", 32)
+	home.Config.ScanSSID = true
+	home.Other = nil
+
+	f.Remove(f.Networks()[1])
+	f.Remove(f.Blob("ca"))
+	f.AddNetwork(wpasupplicant.NetworkConfig{SSID: `guest "wifi"`, KeyMgmt: "NONE"})
+	f.SetGlobal("country", "DE")
+	f.SetGlobal("ap_scan", "1")
+
+	b := &bytes.Buffer{}
+	if _, err = f.WriteTo(b); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `# Managed by the image builder
+ctrl_interface=DIR=/var/run/wpa_supplicant GROUP=netdev
+update_config=1
+country=DE
+ap_scan=1
+
+# Home network
+network={
+	ssid="home # not a comment"
+	psk=` + strings.Repeat("ab", 32) + `
+	key_mgmt=WPA-PSK
+	disabled=0
+	# keep this one
+	bgscan="simple:30:-45:300"
+	scan_ssid=1
+}
+
+
+cred={
+	realm="example.com"
+	username="user"
+	eap=TTLS
+}
+
+network={
+	ssid="guest "wifi""
+	key_mgmt=NONE
+}
+`
+	if b.String() != expect {
+		t.Errorf("got:\n%s\nexpected:\n%s", b.String(), expect)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"network={\n\tssid=\"home\"\n",
+		"network={\n\tpriority=high\n}\n",
+		"blob-base64-ca={\n!!!\n}\n",
+	}
+
+	for _, test := range tests {
+		if _, err := Parse(strings.NewReader(test)); err == nil {
+			t.Errorf("expected error parsing %q", test)
+		}
+	}
+}
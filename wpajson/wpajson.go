@@ -0,0 +1,122 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package wpajson defines the JSON forms of the results returned by a
+// wpasupplicant.Conn, shared by the server package and the wpactl command.
+//
+// SSIDs which aren't printable UTF-8 are escaped, as by
+// wpasupplicant.SSID.String(), since JSON strings can't hold arbitrary
+// bytes.
+package wpajson
+
+import "pifke.org/wpasupplicant"
+
+// Status is the JSON form of wpasupplicant.StatusResult.
+type Status struct {
+	WPAState  string `json:"wpa_state"`
+	SSID      string `json:"ssid,omitempty"`
+	BSSID     string `json:"bssid,omitempty"`
+	Frequency int    `json:"frequency,omitempty"`
+	Channel   int    `json:"channel,omitempty"`
+	KeyMgmt   string `json:"key_mgmt,omitempty"`
+	IPAddr    string `json:"ip_address,omitempty"`
+	Address   string `json:"address,omitempty"`
+}
+
+// NewStatus returns the JSON form of a wpasupplicant.StatusResult.
+func NewStatus(st wpasupplicant.StatusResult) Status {
+	ch, _ := wpasupplicant.FrequencyToChannel(st.Frequency())
+	return Status{
+		WPAState:  st.WPAState(),
+		SSID:      wpasupplicant.SSID(st.SSID()).String(),
+		BSSID:     st.BSSID().String(),
+		Frequency: st.Frequency(),
+		Channel:   ch.Number,
+		KeyMgmt:   st.KeyMgmt(),
+		IPAddr:    st.IPAddr(),
+		Address:   st.Address(),
+	}
+}
+
+// BSS is the JSON form of wpasupplicant.ScanResult.
+type BSS struct {
+	BSSID     string   `json:"bssid"`
+	SSID      string   `json:"ssid"`
+	Frequency int      `json:"frequency"`
+	Channel   int      `json:"channel"`
+	Band      string   `json:"band"`
+	RSSI      int      `json:"rssi"`
+	Flags     []string `json:"flags"`
+}
+
+// NewBSS returns the JSON form of a wpasupplicant.ScanResult.
+func NewBSS(r wpasupplicant.ScanResult) BSS {
+	ch, _ := wpasupplicant.FrequencyToChannel(r.Frequency())
+	return BSS{
+		BSSID:     r.BSSID().String(),
+		SSID:      wpasupplicant.SSID(r.SSID()).String(),
+		Frequency: r.Frequency(),
+		Channel:   ch.Number,
+		Band:      ch.Band.String(),
+		RSSI:      r.RSSI(),
+		Flags:     r.Flags(),
+	}
+}
+
+// Network is the JSON form of wpasupplicant.ConfiguredNetwork.
+type Network struct {
+	ID       int      `json:"id"`
+	SSID     string   `json:"ssid"`
+	BSSID    string   `json:"bssid,omitempty"`
+	Current  bool     `json:"current"`
+	Disabled bool     `json:"disabled"`
+	Flags    []string `json:"flags"`
+}
+
+// NewNetwork returns the JSON form of a wpasupplicant.ConfiguredNetwork.
+func NewNetwork(n wpasupplicant.ConfiguredNetwork) Network {
+	return Network{
+		ID:       n.NetworkID(),
+		SSID:     wpasupplicant.SSID(n.SSID()).String(),
+		BSSID:    n.BSSID().String(),
+		Current:  n.Current(),
+		Disabled: n.Disabled(),
+		Flags:    n.Flags(),
+	}
+}
+
+// ConnectResult is the JSON form of wpasupplicant.ConnectResult, or of a
+// failure to connect.
+type ConnectResult struct {
+	Connected  bool   `json:"connected"`
+	ID         int    `json:"id"`
+	BSSID      string `json:"bssid,omitempty"`
+	Frequency  int    `json:"frequency,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+}
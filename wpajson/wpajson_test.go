@@ -0,0 +1,55 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpajson
+
+import (
+	"net"
+	"testing"
+
+	"pifke.org/wpasupplicant"
+)
+
+type fakeBSS struct {
+	wpasupplicant.ScanResult
+}
+
+func (fakeBSS) BSSID() net.HardwareAddr { return net.HardwareAddr{2, 0, 0, 0, 1, 0} }
+func (fakeBSS) SSID() string            { return "caf\xe9" }
+func (fakeBSS) Frequency() int          { return 5180 }
+func (fakeBSS) RSSI() int               { return -60 }
+func (fakeBSS) Flags() []string         { return []string{"ESS"} }
+
+func TestNewBSS(t *testing.T) {
+	// SSIDs which aren't valid UTF-8 are escaped, rather than being
+	// mangled by encoding/json.
+	b := NewBSS(fakeBSS{})
+	if b.SSID != `caf\xe9` || b.Band != wpasupplicant.Band5GHz.String() {
+		t.Errorf("got %+v", b)
+	}
+}
@@ -34,6 +34,7 @@
 package wpasupplicant
 
 import (
+	"fmt"
 	"net"
 )
 
@@ -90,7 +91,8 @@ type ScanResult interface {
 	// BSSID is the MAC address of the BSS.
 	BSSID() net.HardwareAddr
 
-	// SSID is the SSID of the BSS.
+	// SSID is the raw SSID of the BSS, which may contain arbitrary bytes.
+	// Convert it to an SSID for display.
 	SSID() string
 
 	// Frequency is the frequency, in Mhz, of the BSS.
@@ -122,23 +124,60 @@ func (r *scanResult) Flags() []string         { return r.flags }
 
 // ConfiguredNetwork is a configured network (from LIST_NETWORKS)
 type ConfiguredNetwork interface {
-	NetworkID() string
+	// NetworkID is the ID used to refer to the network in other
+	// commands, such as SelectNetwork().
+	NetworkID() int
+
+	// SSID is the raw SSID of the network, which may contain arbitrary
+	// bytes.  Convert it to an SSID for display.
 	SSID() string
-	BSSID() string
+
+	// BSSID is the BSS the network is restricted to, or nil if it may
+	// use any BSS.
+	BSSID() net.HardwareAddr
+
+	// Flags is an array of flags, in string format, returned by the
+	// wpa_supplicant LIST_NETWORKS command.
 	Flags() []string
+
+	// Current is true if this is the network we're currently using.
+	Current() bool
+
+	// Disabled is true if the network is disabled.
+	Disabled() bool
+
+	// TempDisabled is true if the network has been temporarily disabled,
+	// e.g. after repeated authentication failures.
+	TempDisabled() bool
+
+	// P2PPersistent is true if the network is a persistent P2P group.
+	P2PPersistent() bool
 }
 
 type configuredNetwork struct {
-	networkID string
+	networkID int
 	ssid      string
-	bssid     string // Since bssid can be any
+	bssid     net.HardwareAddr // nil if any
 	flags     []string
 }
 
-func (r *configuredNetwork) NetworkID() string { return r.networkID }
-func (r *configuredNetwork) BSSID() string     { return r.bssid }
-func (r *configuredNetwork) SSID() string      { return r.ssid }
-func (r *configuredNetwork) Flags() []string   { return r.flags }
+func (r *configuredNetwork) NetworkID() int          { return r.networkID }
+func (r *configuredNetwork) BSSID() net.HardwareAddr { return r.bssid }
+func (r *configuredNetwork) SSID() string            { return r.ssid }
+func (r *configuredNetwork) Flags() []string         { return r.flags }
+func (r *configuredNetwork) Current() bool           { return r.hasFlag("CURRENT") }
+func (r *configuredNetwork) Disabled() bool          { return r.hasFlag("DISABLED") }
+func (r *configuredNetwork) TempDisabled() bool      { return r.hasFlag("TEMP-DISABLED") }
+func (r *configuredNetwork) P2PPersistent() bool     { return r.hasFlag("P2P-PERSISTENT") }
+
+func (r *configuredNetwork) hasFlag(flag string) bool {
+	for _, f := range r.flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
 
 type StatusResult interface {
 	WPAState() string
@@ -146,21 +185,32 @@ type StatusResult interface {
 	IPAddr() string
 	SSID() string
 	Address() string
+
+	// BSSID is the MAC address of the BSS we're associated with, if any.
+	BSSID() net.HardwareAddr
+
+	// Frequency is the frequency, in Mhz, of the BSS we're associated
+	// with, if any.
+	Frequency() int
 }
 
 type statusResult struct {
-	wpaState string
-	keyMgmt  string
-	ipAddr   string
-	ssid     string
-	address  string
+	wpaState  string
+	keyMgmt   string
+	ipAddr    string
+	ssid      string
+	address   string
+	bssid     net.HardwareAddr
+	frequency int
 }
 
-func (s *statusResult) WPAState() string { return s.wpaState }
-func (s *statusResult) KeyMgmt() string  { return s.keyMgmt }
-func (s *statusResult) IPAddr() string   { return s.ipAddr }
-func (s *statusResult) SSID() string     { return s.ssid }
-func (s *statusResult) Address() string  { return s.address }
+func (s *statusResult) WPAState() string        { return s.wpaState }
+func (s *statusResult) KeyMgmt() string         { return s.keyMgmt }
+func (s *statusResult) IPAddr() string          { return s.ipAddr }
+func (s *statusResult) SSID() string            { return s.ssid }
+func (s *statusResult) Address() string         { return s.address }
+func (s *statusResult) BSSID() net.HardwareAddr { return s.bssid }
+func (s *statusResult) Frequency() int          { return s.frequency }
 
 type WPAEvent struct {
 	Event     string
@@ -178,18 +228,33 @@ type Conn interface {
 	// responding.
 	Ping() error
 
+	// Command sends a raw control interface command, and returns the
+	// response.  It is used to build support for subsystems, such as
+	// P2P, on top of a Conn.
+	Command(string) (string, error)
+
 	// AddNetwork creates an empty network configuration. Returns the network
 	// ID.
 	AddNetwork() (int, error)
 
 	// SetNetwork configures a network property. Returns error if the property
-	// configuration failed.
+	// configuration failed.  The value is quoted or hex-encoded as required
+	// by the property.
 	SetNetwork(int, string, string) error
 
 	// GetNetwork retrieves a network property. Returns error if the property
 	// retrieval failed.
 	GetNetwork(int, string) (string, error)
 
+	// ApplyNetwork configures a network using the non-zero fields of a
+	// NetworkConfig.  Returns error if the configuration is invalid or
+	// the configuration of any property failed.
+	ApplyNetwork(int, NetworkConfig) error
+
+	// ReadNetwork retrieves the configuration of a network.  Secrets,
+	// such as the PSK, cannot be retrieved.
+	ReadNetwork(int) (NetworkConfig, error)
+
 	// EnableNetwork enables a network. Returns error if the command fails.
 	EnableNetwork(int) error
 
@@ -209,6 +274,19 @@ type Conn interface {
 	// Returns error if command fails.
 	RemoveAllNetworks() error
 
+	// Respond answers a credential request from wpa_supplicant.  See
+	// ParseCredentialRequest().
+	Respond(*CredentialRequest, string) error
+
+	// SetBlob loads a named blob, such as a certificate or private key,
+	// which network configurations can refer to using BlobURL().  Blobs
+	// are limited by the maximum size of a control interface message.
+	SetBlob(string, []byte) error
+
+	// SetSAEPWE sets the mechanism used to derive the SAE password
+	// element.
+	SetSAEPWE(SAEPWE) error
+
 	// SaveConfig stores the current network configuration to disk.
 	SaveConfig() error
 
@@ -230,6 +308,19 @@ type Conn interface {
 	// Status returns current wpa_supplicant status
 	Status() (StatusResult, error)
 
+	// SignalPoll returns the signal strength and rate of the current
+	// link.  Returns error if we're not connected.
+	SignalPoll() (SignalPollResult, error)
+
+	// PacketCountPoll returns the packet counters of the current link.
+	PacketCountPoll() (PacketCountResult, error)
+
+	// SignalMonitor asks wpa_supplicant to send SIGNAL-CHANGE events
+	// when the signal strength crosses a threshold, in dBm, with the
+	// specified hysteresis, in dB.  A threshold of 0 disables
+	// monitoring.  See SignalChanges().
+	SignalMonitor(threshold, hysteresis int) error
+
 	// Scan triggers a new scan. Returns error if the wpa_supplicant does not
 	// return OK.
 	Scan() error
@@ -239,5 +330,44 @@ type Conn interface {
 	// communicating with wpa_supplicant or parsing its output.
 	ScanResults() ([]ScanResult, []error)
 
+	// AbortScan aborts an ongoing scan.
+	AbortScan() error
+
+	// SetScanInterval sets the interval, in seconds, between scans while
+	// wpa_supplicant is looking for a network to connect to.
+	SetScanInterval(int) error
+
+	// Autoscan configures background scanning while connected or
+	// disconnected.  The argument is an autoscan specification, as
+	// returned by AutoscanExponential() or AutoscanPeriodic().  An empty
+	// string disables autoscan.
+	Autoscan(string) error
+
+	// FlushBSS removes BSS entries older than the specified age, in
+	// seconds, from the scan result cache.  An age of 0 flushes all
+	// entries not in use.
+	FlushBSS(int) error
+
+	// SetBSSExpireAge sets the maximum age, in seconds, of entries in the
+	// scan result cache.
+	SetBSSExpireAge(int) error
+
+	// SetBSSExpireCount sets the number of scans after which an entry
+	// not seen is removed from the scan result cache.
+	SetBSSExpireCount(int) error
+
 	EventQueue() chan WPAEvent
 }
+
+// AutoscanExponential returns an autoscan specification which scans with an
+// exponentially increasing interval, starting at base seconds and up to
+// limit seconds.
+func AutoscanExponential(base, limit int) string {
+	return fmt.Sprintf("exponential:%d:%d", base, limit)
+}
+
+// AutoscanPeriodic returns an autoscan specification which scans at a fixed
+// interval, in seconds.
+func AutoscanPeriodic(interval int) string {
+	return fmt.Sprintf("periodic:%d", interval)
+}
@@ -0,0 +1,484 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ErrWPSPINChecksum is returned by WPSControl.CheckPIN() when the PIN's checksum
+// digit is wrong.
+var ErrWPSPINChecksum = errors.New("invalid WPS PIN checksum")
+
+// WPSControl controls Wi-Fi Protected Setup enrollment using a Conn.
+// Progress is reported as WPS-* events on the Conn's EventQueue.  (It isn't
+// named WPS, which is a KeyMgmt.)
+type WPSControl struct {
+	c Conn
+}
+
+// NewWPSControl returns a WPSControl which sends commands using the
+// specified Conn.
+func NewWPSControl(c Conn) *WPSControl {
+	return &WPSControl{c: c}
+}
+
+// wpsTarget formats the BSSID argument of WPS commands.
+func wpsTarget(bssid net.HardwareAddr) string {
+	if bssid == nil {
+		return "any"
+	}
+	return bssid.String()
+}
+
+// PBC starts WPS push-button enrollment with the specified access point,
+// or any access point in push-button mode if bssid is nil.  See
+// WPSPushButton().
+func (w *WPSControl) PBC(bssid net.HardwareAddr) error {
+	return runOK(w.c, "WPS_PBC "+wpsTarget(bssid))
+}
+
+// PIN starts WPS PIN enrollment with the specified access point, or any
+// access point if bssid is nil.  If pin is empty, a random PIN is
+// generated.  Returns the PIN, which must be entered on the access point.
+func (w *WPSControl) PIN(bssid net.HardwareAddr, pin string) (string, error) {
+	cmd := "WPS_PIN " + wpsTarget(bssid)
+	if pin != "" {
+		cmd += " " + pin
+	}
+
+	resp, err := w.c.Command(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	pin = strings.TrimSpace(resp)
+	if pin == "" || strings.HasPrefix(pin, "FAIL") {
+		return "", &ParseError{Line: resp}
+	}
+	return pin, nil
+}
+
+// Reg starts WPS enrollment with the specified access point, acting as an
+// external registrar using the AP PIN printed on the access point.
+func (w *WPSControl) Reg(bssid net.HardwareAddr, apPIN string) error {
+	return runOK(w.c, fmt.Sprintf("WPS_REG %s %s", wpsTarget(bssid), apPIN))
+}
+
+// Cancel cancels any ongoing WPS operation.
+func (w *WPSControl) Cancel() error {
+	return runOK(w.c, "WPS_CANCEL")
+}
+
+// CheckPIN checks the checksum of a WPS PIN.  Returns the PIN with any
+// separators removed, or ErrWPSPINChecksum.
+func (w *WPSControl) CheckPIN(pin string) (string, error) {
+	resp, err := w.c.Command("WPS_CHECK_PIN " + pin)
+	if err != nil {
+		return "", err
+	}
+
+	switch pin = strings.TrimSpace(resp); {
+	case pin == "FAIL-CHECKSUM":
+		return "", ErrWPSPINChecksum
+	case pin == "" || strings.HasPrefix(pin, "FAIL"):
+		return "", &ParseError{Line: resp}
+	}
+	return pin, nil
+}
+
+// WPSConfigError is a WPS Configuration Error code, as reported in the
+// config_error argument of WPS-FAIL events.
+type WPSConfigError int
+
+const (
+	WPSConfigNoError                   WPSConfigError = 0
+	WPSConfigMultiplePBCSessions       WPSConfigError = 12
+	WPSConfigSetupLocked               WPSConfigError = 15
+	WPSConfigMessageTimeout            WPSConfigError = 16
+	WPSConfigRegistrationTimeout       WPSConfigError = 17
+	WPSConfigDevicePasswordAuthFailure WPSConfigError = 18
+)
+
+var wpsConfigErrors = []string{
+	"no error",
+	"OOB interface read error",
+	"decryption CRC failure",
+	"2.4 GHz channel not supported",
+	"5 GHz channel not supported",
+	"signal too weak",
+	"network authentication failure",
+	"network association failure",
+	"no DHCP response",
+	"failed DHCP configuration",
+	"IP address conflict",
+	"couldn't connect to registrar",
+	"multiple PBC sessions detected",
+	"rogue activity suspected",
+	"device busy",
+	"setup locked",
+	"message timeout",
+	"registration session timeout",
+	"device password authentication failure",
+	"60 GHz channel not supported",
+	"public key hash mismatch",
+}
+
+func (e WPSConfigError) String() string {
+	if e >= 0 && int(e) < len(wpsConfigErrors) {
+		return wpsConfigErrors[e]
+	}
+	return fmt.Sprintf("WPSConfigError(%d)", int(e))
+}
+
+// WPSFail is a WPS-FAIL event, which looks like:
+//
+//	WPS-FAIL msg=8 config_error=18
+type WPSFail struct {
+	// Msg is the WPS message type which failed, e.g. 8 for M4.
+	Msg int
+
+	// ConfigError is the reason for the failure.
+	ConfigError WPSConfigError
+
+	// Reason is wpa_supplicant's error indication, if any.
+	Reason int
+}
+
+// ParseWPSFail returns the WPS-FAIL event described by ev.  The second
+// return value is false if ev isn't a WPS-FAIL event.
+func ParseWPSFail(ev WPAEvent) (*WPSFail, bool) {
+	if ev.Event != "WPS-FAIL" {
+		return nil, false
+	}
+
+	f := &WPSFail{}
+	f.Msg, _ = strconv.Atoi(ev.Arguments["msg"])
+	configError, _ := strconv.Atoi(ev.Arguments["config_error"])
+	f.ConfigError = WPSConfigError(configError)
+	f.Reason, _ = strconv.Atoi(ev.Arguments["reason"])
+	return f, true
+}
+
+// WPS authentication and encryption type flags, as used in WPSCredential.
+const (
+	WPSAuthOpen    = 0x0001
+	WPSAuthWPAPSK  = 0x0002
+	WPSAuthShared  = 0x0004
+	WPSAuthWPA     = 0x0008
+	WPSAuthWPA2    = 0x0010
+	WPSAuthWPA2PSK = 0x0020
+
+	WPSEncrNone = 0x0001
+	WPSEncrWEP  = 0x0002
+	WPSEncrTKIP = 0x0004
+	WPSEncrAES  = 0x0008
+)
+
+// WPS attribute types used in credentials.
+const (
+	wpsAttrAuthType   = 0x1003
+	wpsAttrCredential = 0x100e
+	wpsAttrEncrType   = 0x100f
+	wpsAttrMACAddr    = 0x1020
+	wpsAttrNetworkKey = 0x1027
+	wpsAttrSSID       = 0x1045
+)
+
+// WPSCredential is a network credential received from a WPS registrar.
+// wpa_supplicant sends it in a WPS-CRED-RECEIVED event when wps_cred_processing
+// is set to 1 or 2 in wpa_supplicant.conf.
+type WPSCredential struct {
+	// SSID is the raw SSID of the network.
+	SSID string
+
+	// AuthType and EncrType are the network's allowed authentication
+	// and encryption types, e.g. WPSAuthWPA2PSK and WPSEncrAES.
+	AuthType, EncrType uint16
+
+	// NetworkKey is the passphrase or hex PSK of the network.
+	NetworkKey string
+
+	// MACAddr is the address of the enrollee the credential was issued
+	// to.
+	MACAddr net.HardwareAddr
+}
+
+// ParseWPSCredential returns the credential in a WPS-CRED-RECEIVED event.
+// The second return value is false if ev isn't a WPS-CRED-RECEIVED event,
+// or the credential couldn't be parsed.
+func ParseWPSCredential(ev WPAEvent) (*WPSCredential, bool) {
+	fields := strings.Fields(ev.Line)
+	if ev.Event != "WPS-CRED-RECEIVED" || len(fields) < 2 {
+		return nil, false
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(fields[1], "0x"))
+	if err != nil {
+		return nil, false
+	}
+
+	cred := &WPSCredential{}
+	err = parseWPSAttrs(b, func(t uint16, v []byte) {
+		switch t {
+		case wpsAttrCredential:
+			// The event may include the enclosing Credential
+			// attribute.
+			parseWPSAttrs(v, func(t uint16, v []byte) { cred.setAttr(t, v) })
+		default:
+			cred.setAttr(t, v)
+		}
+	})
+	if err != nil || cred.SSID == "" {
+		return nil, false
+	}
+	return cred, true
+}
+
+func (cred *WPSCredential) setAttr(t uint16, v []byte) {
+	switch t {
+	case wpsAttrSSID:
+		cred.SSID = string(v)
+	case wpsAttrAuthType:
+		if len(v) == 2 {
+			cred.AuthType = binary.BigEndian.Uint16(v)
+		}
+	case wpsAttrEncrType:
+		if len(v) == 2 {
+			cred.EncrType = binary.BigEndian.Uint16(v)
+		}
+	case wpsAttrNetworkKey:
+		cred.NetworkKey = string(v)
+	case wpsAttrMACAddr:
+		if len(v) == 6 {
+			cred.MACAddr = net.HardwareAddr(v)
+		}
+	}
+}
+
+// parseWPSAttrs calls fn for each type-length-value attribute in b.
+func parseWPSAttrs(b []byte, fn func(t uint16, v []byte)) error {
+	for len(b) > 0 {
+		if len(b) < 4 {
+			return errors.New("truncated WPS attribute")
+		}
+		t, l := binary.BigEndian.Uint16(b), int(binary.BigEndian.Uint16(b[2:]))
+		if len(b) < 4+l {
+			return errors.New("truncated WPS attribute")
+		}
+		fn(t, b[4:4+l])
+		b = b[4+l:]
+	}
+	return nil
+}
+
+// NetworkConfig returns the configuration of the network described by the
+// credential.
+func (cred *WPSCredential) NetworkConfig() NetworkConfig {
+	cfg := NetworkConfig{SSID: cred.SSID}
+	switch {
+	case cred.AuthType&(WPSAuthWPAPSK|WPSAuthWPA2PSK) != 0:
+		cfg.KeyMgmt = "WPA-PSK"
+		cfg.PSK = cred.NetworkKey
+	case cred.EncrType&WPSEncrWEP != 0:
+		cfg.KeyMgmt = "NONE"
+		cfg.WEPKey0 = cred.NetworkKey
+	default:
+		cfg.KeyMgmt = "NONE"
+	}
+	return cfg
+}
+
+// WPSFailure is the reason a WPS enrollment failed.
+type WPSFailure int
+
+const (
+	// WPSTimeout means the context expired, or wpa_supplicant gave up
+	// after the two minute WPS walk time, before enrollment completed.
+	WPSTimeout WPSFailure = iota
+
+	// WPSOverlap means more than one access point was in push-button
+	// mode.  Specify a BSSID, or try again later.
+	WPSOverlap
+
+	// WPSFailed means the WPS protocol failed.  See
+	// WPSError.ConfigError for the reason.
+	WPSFailed
+
+	// WPSCanceled means the operation was canceled, e.g. by
+	// WPSControl.Cancel().
+	WPSCanceled
+)
+
+func (f WPSFailure) String() string {
+	switch f {
+	case WPSTimeout:
+		return "timed out"
+	case WPSOverlap:
+		return "push-button session overlap"
+	case WPSFailed:
+		return "failed"
+	case WPSCanceled:
+		return "canceled"
+	}
+	return fmt.Sprintf("WPSFailure(%d)", int(f))
+}
+
+// WPSError is returned by WPSPushButton() when enrollment fails.
+type WPSError struct {
+	// Reason is why enrollment failed.
+	Reason WPSFailure
+
+	// ConfigError is the WPS Configuration Error reported by the access
+	// point.  It is only set if Reason is WPSFailed.
+	ConfigError WPSConfigError
+
+	// Line is the event from wpa_supplicant which reported the failure,
+	// if any.
+	Line string
+
+	// Err is any nested error, such as the context's error on timeout.
+	Err error
+}
+
+func (err *WPSError) Error() string {
+	msg := "WPS enrollment " + err.Reason.String()
+
+	if err.Reason == WPSFailed {
+		msg += ": " + err.ConfigError.String()
+	}
+
+	if err.Err != nil {
+		msg += ": " + err.Err.Error()
+	}
+
+	return msg
+}
+
+// WPSResult describes a successful WPS enrollment.
+type WPSResult struct {
+	// NetworkID is the ID of the network added using the credentials
+	// received from the access point.
+	NetworkID int
+
+	// BSSID is the MAC address of the BSS we connected to.
+	BSSID net.HardwareAddr
+}
+
+// WPSPushButton starts WPS push-button enrollment with the specified access
+// point (or any, if bssid is nil), and waits until wpa_supplicant has
+// received credentials and connected using them.  Failures are returned as
+// a *WPSError, after canceling WPS.  Use the context to limit how long to
+// wait.
+//
+// This relies on wpa_supplicant adding a network for the credentials, which
+// it does unless wps_cred_processing is set to 1.
+//
+// WPSPushButton consumes the Conn's EventQueue while it runs, so callers
+// should not be reading events from it at the same time.
+func WPSPushButton(ctx context.Context, c Conn, bssid net.HardwareAddr) (*WPSResult, error) {
+	events := newEventPump(c)
+	defer events.stop()
+
+	w := NewWPSControl(c)
+	if err := w.PBC(bssid); err != nil {
+		return nil, err
+	}
+
+	return waitWPS(ctx, w, events)
+}
+
+// waitWPS waits for an ongoing WPS operation to complete.
+func waitWPS(ctx context.Context, w *WPSControl, events *eventPump) (*WPSResult, error) {
+	fail := func(err *WPSError) (*WPSResult, error) {
+		if err.Reason != WPSCanceled {
+			w.Cancel()
+		}
+		return nil, err
+	}
+
+	succeeded := false
+	for {
+		select {
+		case <-ctx.Done():
+			return fail(&WPSError{
+				Reason: WPSTimeout,
+				Err:    ctx.Err(),
+			})
+
+		case ev := <-events.C:
+			switch ev.Event {
+			case "WPS-SUCCESS":
+				succeeded = true
+
+			case "CONNECTED":
+				// wpa_supplicant connects using the credentials
+				// once WPS succeeds.
+				bssid, id, ok := parseConnectedEvent(ev.Line)
+				if !succeeded || !ok {
+					continue
+				}
+				return &WPSResult{NetworkID: id, BSSID: bssid}, nil
+
+			case "WPS-FAIL":
+				f, _ := ParseWPSFail(ev)
+				return fail(&WPSError{
+					Reason:      WPSFailed,
+					ConfigError: f.ConfigError,
+					Line:        ev.Line,
+				})
+
+			case "WPS-OVERLAP-DETECTED":
+				return fail(&WPSError{
+					Reason: WPSOverlap,
+					Line:   ev.Line,
+				})
+
+			case "WPS-TIMEOUT":
+				return fail(&WPSError{
+					Reason: WPSTimeout,
+					Line:   ev.Line,
+				})
+
+			case "WPS-CANCEL":
+				return fail(&WPSError{
+					Reason: WPSCanceled,
+					Line:   ev.Line,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2017 Dave Pifke.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, is permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice,
+//    this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+// LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+// CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+// SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+// INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+// CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+// ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package wpasupplicant
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wpsServer starts a fakeServer which sends the specified events once WPS
+// push-button enrollment starts.
+func wpsServer(t *testing.T, events ...string) (*fakeServer, Conn) {
+	var fs *fakeServer
+	fs, conn := newFakeServer(t, func(cmd string) string {
+		switch {
+		case strings.HasPrefix(cmd, "WPS_PBC"):
+			go func() {
+				for _, ev := range events {
+					fs.event(ev)
+				}
+			}()
+		case strings.HasPrefix(cmd, "WPS_PIN "):
+			return "12345670\n"
+		case cmd == "WPS_CHECK_PIN 1234-5670":
+			return "12345670\n"
+		case cmd == "WPS_CHECK_PIN 12345678":
+			return "FAIL-CHECKSUM\n"
+		}
+		return ""
+	})
+	return fs, conn
+}
+
+func TestWPSCommands(t *testing.T) {
+	fs, conn := wpsServer(t)
+	w := NewWPSControl(conn)
+	bssid := net.HardwareAddr{2, 0, 0, 0, 1, 0}
+
+	if err := w.PBC(nil); err != nil {
+		t.Error(err)
+	}
+	if err := w.PBC(bssid); err != nil {
+		t.Error(err)
+	}
+	if pin, err := w.PIN(nil, ""); err != nil || pin != "12345670" {
+		t.Errorf("PIN: got %q, %v", pin, err)
+	}
+	if err := w.Reg(bssid, "12345670"); err != nil {
+		t.Error(err)
+	}
+	if err := w.Cancel(); err != nil {
+		t.Error(err)
+	}
+	if pin, err := w.CheckPIN("1234-5670"); err != nil || pin != "12345670" {
+		t.Errorf("CheckPIN: got %q, %v", pin, err)
+	}
+	if _, err := w.CheckPIN("12345678"); err != ErrWPSPINChecksum {
+		t.Errorf("CheckPIN: got %v, expected ErrWPSPINChecksum", err)
+	}
+
+	fs.expectCommands(
+		"WPS_PBC any",
+		"WPS_PBC 02:00:00:00:01:00",
+		"WPS_PIN any",
+		"WPS_REG 02:00:00:00:01:00 12345670",
+		"WPS_CANCEL",
+		"WPS_CHECK_PIN 1234-5670",
+		"WPS_CHECK_PIN 12345678",
+	)
+}
+
+func TestWPSPushButton(t *testing.T) {
+	fs, conn := wpsServer(t,
+		"WPS-PBC-ACTIVE ",
+		"WPS-CRED-RECEIVED 100e0008104500046e657431",
+		"WPS-SUCCESS ",
+		"CTRL-EVENT-CONNECTED - Connection to 02:00:00:00:01:00 completed [id=1 id_str=]")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := WPSPushButton(ctx, conn, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NetworkID != 1 {
+		t.Errorf("wrong network id (got %d, expect 1)", res.NetworkID)
+	}
+	if bytes.Compare(res.BSSID, net.HardwareAddr{2, 0, 0, 0, 1, 0}) != 0 {
+		t.Errorf("wrong bssid (got %s)", res.BSSID)
+	}
+
+	fs.expectCommands("WPS_PBC any")
+}
+
+func TestWPSPushButtonFailure(t *testing.T) {
+	tests := []struct {
+		event       string
+		reason      WPSFailure
+		configError WPSConfigError
+	}{
+		{
+			event:       "WPS-FAIL msg=8 config_error=18",
+			reason:      WPSFailed,
+			configError: WPSConfigDevicePasswordAuthFailure,
+		}, {
+			event:  "WPS-OVERLAP-DETECTED PBC session overlap",
+			reason: WPSOverlap,
+		}, {
+			event:  "WPS-TIMEOUT Requested operation timed out",
+			reason: WPSTimeout,
+		},
+	}
+
+	for _, test := range tests {
+		fs, conn := wpsServer(t, test.event)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := WPSPushButton(ctx, conn, nil)
+		cancel()
+
+		werr, ok := err.(*WPSError)
+		if !ok {
+			t.Errorf("%s: expected *WPSError, got %v", test.event, err)
+			continue
+		}
+		if werr.Reason != test.reason || werr.ConfigError != test.configError {
+			t.Errorf("%s: got %s", test.event, werr)
+		}
+
+		fs.expectCommands("WPS_PBC any", "WPS_CANCEL")
+	}
+}
+
+func TestParseWPSCredential(t *testing.T) {
+	// SSID "home", WPA2-PSK, AES, key "password", MAC 02:00:00:00:00:01,
+	// inside a Credential attribute.
+	ev := WPAEvent{
+		Event: "WPS-CRED-RECEIVED",
+		Line: "WPS-CRED-RECEIVED 100e002a" +
+			"10450004686f6d65" +
+			"100300020020" +
+			"100f00020008" +
+			"10270008" + "70617373776f7264" +
+			"10200006020000000001",
+	}
+
+	cred, ok := ParseWPSCredential(ev)
+	if !ok {
+		t.Fatal("failed to parse credential")
+	}
+	if cred.SSID != "home" || cred.AuthType != WPSAuthWPA2PSK || cred.EncrType != WPSEncrAES ||
+		cred.NetworkKey != "password" || cred.MACAddr.String() != "02:00:00:00:00:01" {
+		t.Errorf("got %+v", cred)
+	}
+
+	cfg := cred.NetworkConfig()
+	if cfg.SSID != "home" || cfg.KeyMgmt != "WPA-PSK" || cfg.PSK != "password" {
+		t.Errorf("got config %+v", cfg)
+	}
+
+	ev.Line = "WPS-CRED-RECEIVED 100e00ff"
+	if _, ok = ParseWPSCredential(ev); ok {
+		t.Error("parsed truncated credential")
+	}
+}
+
+func TestParseWPSFail(t *testing.T) {
+	f, ok := ParseWPSFail(WPAEvent{
+		Event:     "WPS-FAIL",
+		Arguments: map[string]string{"msg": "8", "config_error": "15", "reason": "2"},
+	})
+	if !ok || f.Msg != 8 || f.ConfigError != WPSConfigSetupLocked || f.Reason != 2 {
+		t.Errorf("got %+v", f)
+	}
+	if f.ConfigError.String() != "setup locked" {
+		t.Errorf("got %q", f.ConfigError)
+	}
+
+	if _, ok = ParseWPSFail(WPAEvent{Event: "WPS-SUCCESS"}); ok {
+		t.Error("parsed WPS-SUCCESS as WPS-FAIL")
+	}
+}